@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/app"
+	"go-test-grpc-http/internal/db"
+	"go-test-grpc-http/internal/repository"
+	"go-test-grpc-http/internal/usecase"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/jmoiron/sqlx"
+)
+
+// addCommands добавляет к parser подкоманды приложения. Без подкоманды выполняется serve.
+// Подкоманды получают параметры cfg после их загрузки и проверки.
+func addCommands(parser *flags.Parser, cfg *config.Config) error {
+	parser.SubcommandsOptional = true
+
+	commands := []struct {
+		name        string
+		description string
+		data        interface{}
+		subcommands []subcommand
+	}{
+		{name: "serve", description: "Start HTTP and gRPC servers (default)", data: &serveCommand{cfg: cfg}},
+		{name: "migrate", description: "Manage database schema migrations", data: &struct{}{}, subcommands: []subcommand{
+			{"up", "Apply all pending migrations", &migrateUpCommand{cfg: cfg}},
+			{"down", "Roll back the last migrations", &migrateDownCommand{cfg: cfg}},
+			{"goto", "Migrate up or down to the given version", &migrateGotoCommand{cfg: cfg}},
+			{"version", "Print current schema version", &migrateVersionCommand{cfg: cfg}},
+			{"force", "Set schema version without running migrations and clear the dirty flag", &migrateForceCommand{cfg: cfg}},
+		}},
+		{name: "user", description: "Manage users", data: &struct{}{}, subcommands: []subcommand{
+			{"create", "Create a user", &userCreateCommand{cfg: cfg}},
+			{"set-role", "Change user role", &userSetRoleCommand{cfg: cfg}},
+			{"disable", "Disable or re-enable user sign in", &userDisableCommand{cfg: cfg}},
+			{"reset-password", "Set a new user password (not checked on sign in yet)", &userResetPasswordCommand{cfg: cfg}},
+		}},
+		{name: "token", description: "Issue access tokens for debugging", data: &struct{}{}, subcommands: []subcommand{
+			{"issue", "Issue JWT for a user", &tokenIssueCommand{cfg: cfg}},
+		}},
+		{name: "config", description: "Check application config", data: &struct{}{}, subcommands: []subcommand{
+			{"check", "Validate config and exit", &configCheckCommand{cfg: cfg}},
+		}},
+	}
+
+	for _, c := range commands {
+		command, err := parser.AddCommand(c.name, c.description, "", c.data)
+		if err != nil {
+			return fmt.Errorf("can't add command %s: %w", c.name, err)
+		}
+		for _, sub := range c.subcommands {
+			_, err := command.AddCommand(sub.name, sub.description, "", sub.data)
+			if err != nil {
+				return fmt.Errorf("can't add command %s %s: %w", c.name, sub.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type subcommand struct {
+	name        string
+	description string
+	data        flags.Commander
+}
+
+// commandContext контекст административной команды, отменяется по SIGINT/SIGTERM
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// openUsers подключается к БД и возвращает сценарии использования пользователей,
+// те же, что обслуживают HTTP и gRPC. Соединение закрывается через close.
+func openUsers(ctx context.Context, cfg *config.Config) (interactor usecase.UserInteractor, close func() error, err error) {
+	conn, err := openDB(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return usecase.NewUserInteractor(repository.NewUserRepository(db.NewSource(conn))), conn.Close, nil
+}
+
+func openDB(ctx context.Context, cfg *config.Config) (*sqlx.DB, error) {
+	if cfg.Storage != app.StoragePostgres {
+		return nil, fmt.Errorf("command requires %s storage, got %s", app.StoragePostgres, cfg.Storage)
+	}
+	conn, err := app.OpenDB(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to db: %w", err)
+	}
+
+	return conn, nil
+}
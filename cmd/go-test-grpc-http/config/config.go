@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/jessevdk/go-flags"
 	"github.com/joho/godotenv"
@@ -11,15 +15,29 @@ import (
 
 const envfile = "dev/.env"
 
+// DateLayout формат дат в параметрах
+const DateLayout = "2006-01-02"
+
+// Config параметры приложения. Источники в порядке возрастания приоритета:
+// значения по умолчанию, файл конфигурации (--config), переменные окружения, флаги командной строки.
+//
+// Параметры с тегом secret можно передать файлом через переменную окружения <ENV>_FILE,
+// их значения скрываются в --print-config.
+// Параметры с тегом reload применяются без перезапуска при изменении файла конфигурации или по SIGHUP.
 type Config struct {
-	LogLevel    string `long:"log-level" description:"Log level: panic, fatal, warn, debug, info" env:"LOG_LEVEL" default:"info"`
+	ConfigFile  string `long:"config" description:"Path to YAML or TOML config file" env:"CONFIG_FILE" no-file:"true"`
+	PrintConfig bool   `long:"print-config" description:"Print effective config with secrets redacted and exit" no-file:"true"`
+
+	LogLevel    string `long:"log-level" description:"Log level: panic, fatal, warn, debug, info" env:"LOG_LEVEL" default:"info" reload:"true"`
 	Environment string `long:"env" description:"App environment" env:"ENV" default:"develop"`
 
 	Debug   bool   `long:"debug" description:"Developer mode" env:"DEBUG"`
 	DevMode bool   `long:"dev_mode" description:"Developer mode" env:"DEV_MODE"`
 	PathLog string `long:"path_log" description:"Path log" env:"PATH_LOG" default:"stdout"`
 
-	ApiKey string `long:"api-key" description:"Api key for authentification" env:"APIKEY" default:"apikey"`
+	ShutdownTimeout time.Duration `long:"shutdown_timeout" description:"Deadline of each graceful shutdown phase" env:"SHUTDOWN_TIMEOUT" default:"15s"`
+
+	ApiKey string `long:"api-key" description:"Api key for authentification and JWT signing" env:"APIKEY" default:"apikey" secret:"true"`
 
 	AppInfo struct {
 		Name    string `long:"name" description:"App name" env:"APP_NAME" required:"true" default:"default app"`
@@ -31,9 +49,46 @@ type Config struct {
 		Port int    `long:"http_port" description:"Post HTTP sever" env:"HTTP_PORT" required:"true" default:"80"`
 	}
 
+	Cors struct {
+		Origins []string `long:"cors_origin" description:"Allowed CORS origin, * allows any" env:"CORS_ORIGINS" env-delim:"," default:"*" reload:"true"`
+	}
+
+	RateLimit struct {
+		RPS   float64 `long:"rate_limit_rps" description:"Requests per second per client for HTTP and gRPC, 0 disables the limit" env:"RATE_LIMIT_RPS" default:"0" reload:"true"`
+		Burst int     `long:"rate_limit_burst" description:"Maximum burst of requests over the limit" env:"RATE_LIMIT_BURST" default:"100" reload:"true"`
+	}
+
+	Auth struct {
+		TokenTTL time.Duration `long:"token_ttl" description:"Lifetime of issued JWT" env:"TOKEN_TTL" default:"24h" reload:"true"`
+	}
+
+	Storage string `long:"storage" description:"User storage implementation, memory keeps data until restart" env:"STORAGE" choice:"postgres" choice:"memory" default:"postgres"`
+
+	SinglePort bool `long:"single_port" description:"Serve gRPC, gRPC-Web and HTTP on the HTTP server port" env:"SINGLE_PORT"`
+
 	GrpcServer struct {
-		Host string `long:"grcp_host" description:"Host gRCP server" env:"gRCP_HOST" required:"true" default:"0.0.0.0"`
-		Port int    `long:"grcp_port" description:"Port gRCP server" env:"gRCP_PORT" required:"true" default:"9000"`
+		Host string `long:"grpc_host" description:"Host gRPC server" env:"GRPC_HOST" required:"true" default:"0.0.0.0"`
+		Port int    `long:"grpc_port" description:"Port gRPC server" env:"GRPC_PORT" required:"true" default:"9000"`
+	}
+
+	LegacyAPI struct {
+		DeprecatedAt string `long:"legacy_api_deprecated_at" description:"Date (YYYY-MM-DD) since which the /api/v0.0.1 prefix is deprecated" env:"LEGACY_API_DEPRECATED_AT" default:"2026-10-19"`
+		SunsetAt     string `long:"legacy_api_sunset_at" description:"Date (YYYY-MM-DD) after which the /api/v0.0.1 prefix is no longer served" env:"LEGACY_API_SUNSET_AT" default:"2027-04-19"`
+	}
+
+	Gateway struct {
+		Prefix string `long:"gateway_prefix" description:"Path prefix of HTTP/JSON gateway to gRPC services" env:"GATEWAY_PREFIX" default:"/gateway"`
+	}
+
+	Connect struct {
+		Prefix string `long:"connect_prefix" description:"Path prefix of Connect protocol handlers" env:"CONNECT_PREFIX" default:"/connect"`
+	}
+
+	Tracing struct {
+		Exporter    string  `long:"tracing_exporter" description:"Trace exporter: none, otlp, stdout" env:"TRACING_EXPORTER" default:"none"`
+		Endpoint    string  `long:"tracing_endpoint" description:"OTLP/gRPC collector endpoint" env:"TRACING_ENDPOINT" default:"localhost:4317"`
+		File        string  `long:"tracing_file" description:"File for stdout exporter, empty for stdout" env:"TRACING_FILE"`
+		SampleRatio float64 `long:"tracing_sample_ratio" description:"Share of traced requests from 0 to 1" env:"TRACING_SAMPLE_RATIO" default:"1"`
 	}
 
 	DB struct {
@@ -41,38 +96,115 @@ type Config struct {
 		Port     int    `long:"db_port" description:"Port DB" env:"DB_PORT" required:"true" default:"5432"`
 		Name     string `long:"db_name" description:"Name DB" env:"DB_NAME" required:"true" default:"db"`
 		Username string `long:"db_username" description:"Username DB" env:"DB_USER" required:"true" default:"dbuser"`
-		Password string `long:"db_password" description:"Password DB" env:"DB_PASS" required:"true" default:"dbpass"`
+		Password string `long:"db_password" description:"Password DB" env:"DB_PASS" required:"true" default:"dbpass" secret:"true"`
 		SSLMode  string `long:"db_sslmode" description:"SSLMode DB" env:"DB_SSLMODE" required:"true" default:"disable"`
 	}
+
+	Migrate struct {
+		Skip            bool          `long:"migrate_skip" description:"Don't apply migrations on start, only check schema state" env:"MIGRATE_SKIP"`
+		AllowDirty      bool          `long:"migrate_allow_dirty" description:"Start even if migrations failed or the schema is dirty" env:"MIGRATE_ALLOW_DIRTY"`
+		ExpectedVersion uint          `long:"migrate_expected_version" description:"Schema version required on start, 0 disables the check" env:"MIGRATE_EXPECTED_VERSION"`
+		LockTimeout     time.Duration `long:"migrate_lock_timeout" description:"How long to wait for the migration lock held by another replica" env:"MIGRATE_LOCK_TIMEOUT" default:"1m"`
+	}
+
+	Export struct {
+		Dir string `long:"export_dir" description:"Directory for async export results" env:"EXPORT_DIR" default:"exports"`
+	}
 }
 
 var (
-	appConfig     *Config
+	appConfig     atomic.Pointer[Config]
 	appConfigOnce sync.Once
 )
 
-func newConfig() (*Config, error) {
+func newConfig(args []string) (*Config, error) {
+	var cfg Config
+	if err := Parse(NewParser(&cfg), &cfg, args); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// NewParser создает парсер параметров приложения в cfg. До вызова Parse к нему можно добавить подкоманды.
+func NewParser(cfg *Config) *flags.Parser {
+	return flags.NewParser(cfg, flags.Default|flags.IgnoreUnknown)
+}
+
+// Parse загружает в cfg параметры из всех источников и проверяет их.
+// Подкоманды parser разбираются вместе с параметрами.
+func Parse(parser *flags.Parser, cfg *Config, args []string) error {
 	godotenv.Load(envfile)
 
-	var cfg Config
-	parser := flags.NewParser(&cfg, flags.Default|flags.IgnoreUnknown)
-	_, err := parser.Parse()
+	// Файл конфигурации и секреты из файлов подставляются как значения по умолчанию,
+	// поэтому переменные окружения и флаги их переопределяют
+	var errs []error
+	path, err := configFilePath(args)
+	if err != nil {
+		return fmt.Errorf("config parse failed: %w", err)
+	}
+	if path != "" {
+		errs = append(errs, applyFile(parser, path))
+	}
+	applyLegacyEnv(parser)
+	errs = append(errs, applySecretFiles(parser))
+
+	_, err = parser.ParseArgs(args)
+	if flags.WroteHelp(err) {
+		return err
+	}
 	if err != nil {
 		parser.WriteHelp(log.Writer())
-		return nil, fmt.Errorf("config parse failed: %v", err)
+		return fmt.Errorf("config parse failed: %v", err)
 	}
 
-	return &cfg, nil
+	// Ошибки загрузки и проверки сообщаются вместе
+	errs = append(errs, cfg.Validate())
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	return nil
+}
+
+// SetAppConfig задает параметры приложения, загруженные через Parse
+func SetAppConfig(cfg *Config) {
+	appConfigOnce.Do(func() {})
+	appConfig.Store(cfg)
 }
 
 func GetAppConfig() (*Config, error) {
 	appConfigOnce.Do(func() {
-		config, err := newConfig()
+		config, err := newConfig(os.Args[1:])
 		if err != nil {
 			log.Fatalf("can't load config: %v", err)
 		}
-		appConfig = config
+		appConfig.Store(config)
 	})
 
-	return appConfig, nil
+	return appConfig.Load(), nil
+}
+
+// AllowOrigin проверяет источник запроса по текущему списку CORS_ORIGINS, который меняется без перезапуска.
+// Общая проверка для HTTP и gRPC-Web.
+func AllowOrigin(origin string) bool {
+	cfg, err := GetAppConfig()
+	if err != nil {
+		return false
+	}
+	for _, allowed := range cfg.Cors.Origins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LegacyAPIDates возвращает даты вывода из эксплуатации устаревшего префикса API.
+// Формат дат проверяется в Validate.
+func (c *Config) LegacyAPIDates() (deprecatedAt, sunsetAt time.Time) {
+	deprecatedAt, _ = time.Parse(DateLayout, c.LegacyAPI.DeprecatedAt)
+	sunsetAt, _ = time.Parse(DateLayout, c.LegacyAPI.SunsetAt)
+	return deprecatedAt, sunsetAt
 }
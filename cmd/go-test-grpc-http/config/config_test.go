@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func Test_newConfig_precedence(t *testing.T) {
+	yamlFile := writeFile(t, "config.yaml", "http_port: 8001\ngrpc_port: 9001\ndb_host: file\nlog-level: warn\nlegacy_api_sunset_at: 2030-07-01\n")
+	tomlFile := writeFile(t, "config.toml", "http_port = 8001\ngrpc_port = 9001\ndb_host = \"file\"\nlegacy_api_sunset_at = 2030-07-01\n")
+
+	tests := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		wantHTTP   int
+		wantGRPC   int
+		wantHost   string
+		wantSunset string
+	}{
+		{
+			name:       "yaml file overrides defaults",
+			args:       []string{"--config", yamlFile},
+			wantHTTP:   8001,
+			wantGRPC:   9001,
+			wantHost:   "file",
+			wantSunset: "2030-07-01",
+		},
+		{
+			name:       "toml file from env",
+			env:        map[string]string{"CONFIG_FILE": tomlFile},
+			wantHTTP:   8001,
+			wantGRPC:   9001,
+			wantHost:   "file",
+			wantSunset: "2030-07-01",
+		},
+		{
+			name:       "env overrides file, flag overrides env",
+			args:       []string{"--config", yamlFile, "--http_port", "8003"},
+			env:        map[string]string{"HTTP_PORT": "8002", "DB_HOST": "env"},
+			wantHTTP:   8003,
+			wantGRPC:   9001,
+			wantHost:   "env",
+			wantSunset: "2030-07-01",
+		},
+		{
+			name:       "legacy env name",
+			env:        map[string]string{"gRCP_PORT": "9002"},
+			wantHTTP:   80,
+			wantGRPC:   9002,
+			wantHost:   "127.0.0.1",
+			wantSunset: "2027-04-19",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := newConfig(tt.args)
+			if err != nil {
+				t.Fatalf("newConfig() error = %v", err)
+			}
+			if cfg.LegacyAPI.SunsetAt != tt.wantSunset {
+				t.Errorf("newConfig() legacy_api_sunset_at = %q, want %q", cfg.LegacyAPI.SunsetAt, tt.wantSunset)
+			}
+			if cfg.HttpServer.Port != tt.wantHTTP || cfg.GrpcServer.Port != tt.wantGRPC || cfg.DB.Host != tt.wantHost {
+				t.Errorf("newConfig() = http %d, grpc %d, db %s, want http %d, grpc %d, db %s",
+					cfg.HttpServer.Port, cfg.GrpcServer.Port, cfg.DB.Host, tt.wantHTTP, tt.wantGRPC, tt.wantHost)
+			}
+		})
+	}
+}
+
+func Test_newConfig_secretFile(t *testing.T) {
+	t.Setenv("DB_PASS_FILE", writeFile(t, "db_pass", "s3cr3t\n"))
+	t.Setenv("APIKEY", "signing-key")
+
+	cfg, err := newConfig(nil)
+	if err != nil {
+		t.Fatalf("newConfig() error = %v", err)
+	}
+	if cfg.DB.Password != "s3cr3t" {
+		t.Errorf("DB.Password = %q, want %q", cfg.DB.Password, "s3cr3t")
+	}
+	if out := cfg.String(); strings.Contains(out, "s3cr3t") || strings.Contains(out, "signing-key") || !strings.Contains(out, "db_password: '******'") {
+		t.Errorf("String() doesn't redact secret:\n%s", out)
+	}
+
+	t.Setenv("DB_PASS", "other")
+	if _, err := newConfig(nil); err == nil {
+		t.Errorf("newConfig() with DB_PASS and DB_PASS_FILE error = nil")
+	}
+}
+
+func Test_newConfig_reportsAllProblems(t *testing.T) {
+	path := writeFile(t, "config.yaml", "unknown_key: 1\nprint-config: true\n")
+	t.Setenv("LOG_LEVEL", "loud")
+	t.Setenv("TRACING_SAMPLE_RATIO", "2")
+	t.Setenv("MIGRATE_LOCK_TIMEOUT", "0s")
+	t.Setenv("LEGACY_API_SUNSET_AT", "2026-01-01")
+
+	_, err := newConfig([]string{"--config", path, "--grpc_port", "80"})
+	if err == nil {
+		t.Fatalf("newConfig() error = nil")
+	}
+	for _, want := range []string{"unknown key unknown_key", "print-config can't be set", "log-level", "tracing_sample_ratio", "grpc_port", "migrate_lock_timeout", "legacy_api_sunset_at: must be after"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("newConfig() error = %v, want to contain %q", err, want)
+		}
+	}
+}
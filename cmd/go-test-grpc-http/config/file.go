@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Устаревшие имена переменных окружения и их замена
+var legacyEnv = map[string]string{
+	"gRCP_HOST":   "GRPC_HOST",
+	"gRCP_PORT":   "GRPC_PORT",
+	"ENVIRONMENT": "ENV",
+}
+
+// configFilePath находит путь к файлу конфигурации во флаге --config или переменной CONFIG_FILE
+func configFilePath(args []string) (string, error) {
+	var opts struct {
+		ConfigFile string `long:"config" env:"CONFIG_FILE"`
+	}
+	_, err := flags.NewParser(&opts, flags.IgnoreUnknown).ParseArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	return opts.ConfigFile, nil
+}
+
+// applyFile подставляет значения из файла конфигурации как значения по умолчанию параметров.
+// Ключи файла совпадают с длинными именами флагов, например db_host или log-level.
+func applyFile(parser *flags.Parser, path string) error {
+	values, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, option := range options(parser) {
+		value, ok := values[option.LongName]
+		if !ok {
+			continue
+		}
+		delete(values, option.LongName)
+		if option.Field().Tag.Get("no-file") == "true" {
+			errs = append(errs, fmt.Errorf("%s: %s can't be set in config file", path, option.LongName))
+			continue
+		}
+		option.Default = fileValues(value)
+	}
+
+	unknown := make([]string, 0, len(values))
+	for key := range values {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("%s: unknown key %s", path, key))
+	}
+
+	return errors.Join(errs...)
+}
+
+func readFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read config file: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q, want .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't parse config file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// fileValues приводит значение из файла к строкам, которые понимает go-flags
+func fileValues(value interface{}) []string {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice {
+		return []string{fileValue(value)}
+	}
+
+	values := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, fileValue(v.Index(i).Interface()))
+	}
+
+	return values
+}
+
+// fileValue приводит скалярное значение из файла к строке. Даты без кавычек YAML разбирает в time.Time,
+// они передаются в формате DateLayout или RFC 3339, если указано время.
+func fileValue(value interface{}) string {
+	t, ok := value.(time.Time)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(DateLayout)
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// applyLegacyEnv принимает значения из устаревших переменных окружения, если не задана новая
+func applyLegacyEnv(parser *flags.Parser) {
+	for _, option := range options(parser) {
+		for legacy, env := range legacyEnv {
+			if option.EnvDefaultKey != env {
+				continue
+			}
+			value, ok := os.LookupEnv(legacy)
+			if !ok {
+				continue
+			}
+			if _, ok := os.LookupEnv(env); ok {
+				continue
+			}
+			log.Printf("config: %s is deprecated, use %s", legacy, env)
+			option.Default = []string{value}
+		}
+	}
+}
+
+// applySecretFiles читает секреты из файлов, указанных в переменных окружения <ENV>_FILE
+func applySecretFiles(parser *flags.Parser) error {
+	var errs []error
+	for _, option := range options(parser) {
+		if !isSecret(option.Field()) || option.EnvDefaultKey == "" {
+			continue
+		}
+		fileEnv := option.EnvDefaultKey + "_FILE"
+		path, ok := os.LookupEnv(fileEnv)
+		if !ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(option.EnvDefaultKey); ok {
+			errs = append(errs, fmt.Errorf("only one of %s and %s can be set", option.EnvDefaultKey, fileEnv))
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't read %s: %w", fileEnv, err))
+			continue
+		}
+		option.Default = []string{strings.TrimRight(string(data), "\r\n")}
+	}
+
+	return errors.Join(errs...)
+}
+
+// options возвращает все параметры парсера
+func options(parser *flags.Parser) []*flags.Option {
+	var result []*flags.Option
+	var walk func(group *flags.Group)
+	walk = func(group *flags.Group) {
+		result = append(result, group.Options()...)
+		for _, g := range group.Groups() {
+			walk(g)
+		}
+	}
+	walk(parser.Command.Group)
+
+	return result
+}
+
+func isSecret(field reflect.StructField) bool {
+	return field.Tag.Get("secret") == "true"
+}
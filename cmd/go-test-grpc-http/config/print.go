@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Значение, которым заменяются секреты при выводе
+const redacted = "******"
+
+// Print выводит действующие параметры в формате файла конфигурации, скрывая секреты
+func (c *Config) Print(w io.Writer) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	err := appendFields(root, reflect.ValueOf(c).Elem())
+	if err != nil {
+		return fmt.Errorf("can't print config: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(root)
+}
+
+// String возвращает параметры со скрытыми секретами
+func (c *Config) String() string {
+	var b strings.Builder
+	if err := c.Print(&b); err != nil {
+		return err.Error()
+	}
+	return b.String()
+}
+
+func appendFields(node *yaml.Node, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		name := field.Tag.Get("long")
+		if name == "" {
+			if field.Type.Kind() == reflect.Struct {
+				if err := appendFields(node, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if field.Tag.Get("no-file") == "true" {
+			continue
+		}
+
+		var out interface{} = value.Interface()
+		switch {
+		case isSecret(field) && !value.IsZero():
+			out = redacted
+		case value.Type().Implements(stringerType):
+			out = value.Interface().(fmt.Stringer).String()
+		}
+
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(out); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, valueNode)
+	}
+
+	return nil
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"reflect"
+)
+
+// Reload перечитывает параметры из тех же источников, что и при запуске, и проверяет их.
+// Текущие параметры не меняются, см. Apply.
+func Reload() (*Config, error) {
+	return newConfig(os.Args[1:])
+}
+
+// Apply применяет к текущим параметрам изменения из next, допустимые без перезапуска, и атомарно заменяет их.
+// Возвращает имена примененных параметров и параметров, изменение которых требует перезапуска и было отклонено.
+func Apply(next *Config) (applied, rejected []string) {
+	current, _ := GetAppConfig()
+	updated := *current
+
+	diffFields(reflect.ValueOf(&updated).Elem(), reflect.ValueOf(next).Elem(), func(name string, field reflect.StructField, dst, src reflect.Value) {
+		if field.Tag.Get("reload") != "true" {
+			rejected = append(rejected, name)
+			return
+		}
+		dst.Set(src)
+		applied = append(applied, name)
+	})
+	if len(applied) > 0 {
+		appConfig.Store(&updated)
+	}
+
+	return applied, rejected
+}
+
+// diffFields вызывает changed для каждого параметра, значение которого в src отличается от dst
+func diffFields(dst, src reflect.Value, changed func(name string, field reflect.StructField, dst, src reflect.Value)) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("long")
+		if name == "" {
+			if field.Type.Kind() == reflect.Struct {
+				diffFields(dst.Field(i), src.Field(i), changed)
+			}
+			continue
+		}
+		if field.Tag.Get("no-file") == "true" {
+			continue
+		}
+		if !reflect.DeepEqual(dst.Field(i).Interface(), src.Field(i).Interface()) {
+			changed(name, field, dst.Field(i), src.Field(i))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	current, err := newConfig(nil)
+	if err != nil {
+		t.Fatalf("newConfig() error = %v", err)
+	}
+	appConfigOnce.Do(func() {})
+	appConfig.Store(current)
+
+	next := *current
+	next.LogLevel = "debug"
+	next.Cors.Origins = []string{"https://example.com"}
+	next.Auth.TokenTTL = time.Hour
+	next.DB.Host = "other"
+	next.HttpServer.Port = 8081
+
+	applied, rejected := Apply(&next)
+
+	if want := []string{"log-level", "cors_origin", "token_ttl"}; !reflect.DeepEqual(applied, want) {
+		t.Errorf("Apply() applied = %v, want %v", applied, want)
+	}
+	if want := []string{"http_port", "db_host"}; !reflect.DeepEqual(rejected, want) {
+		t.Errorf("Apply() rejected = %v, want %v", rejected, want)
+	}
+
+	got, _ := GetAppConfig()
+	if got.LogLevel != "debug" || got.Auth.TokenTTL != time.Hour || got.DB.Host != current.DB.Host || got.HttpServer.Port != current.HttpServer.Port {
+		t.Errorf("GetAppConfig() after Apply() = %+v", got)
+	}
+	if current.LogLevel == "debug" {
+		t.Errorf("Apply() changed previous config in place")
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Экспортеры трассировки, см. tracing.Config
+var tracingExporters = []string{"none", "otlp", "stdout"}
+
+// Validate проверяет параметры и возвращает все найденные ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	_, err := zapcore.ParseLevel(c.LogLevel)
+	check(err == nil, "log-level: unknown level %q", c.LogLevel)
+	check(c.ShutdownTimeout > 0, "shutdown_timeout: must be positive, got %s", c.ShutdownTimeout)
+	check(c.ApiKey != "", "api-key: must not be empty")
+	check(c.Auth.TokenTTL > 0, "token_ttl: must be positive, got %s", c.Auth.TokenTTL)
+	check(len(c.Cors.Origins) > 0, "cors_origin: at least one origin is required")
+	check(c.RateLimit.RPS >= 0, "rate_limit_rps: must not be negative, got %v", c.RateLimit.RPS)
+	check(c.RateLimit.RPS == 0 || c.RateLimit.Burst > 0, "rate_limit_burst: must be positive, got %d", c.RateLimit.Burst)
+
+	check(validPort(c.HttpServer.Port), "http_port: must be in range 1-65535, got %d", c.HttpServer.Port)
+	check(validPort(c.GrpcServer.Port), "grpc_port: must be in range 1-65535, got %d", c.GrpcServer.Port)
+	check(c.SinglePort || c.HttpServer.Port != c.GrpcServer.Port,
+		"grpc_port: must differ from http_port %d unless single_port is set", c.HttpServer.Port)
+	deprecatedAt, err := time.Parse(DateLayout, c.LegacyAPI.DeprecatedAt)
+	check(err == nil, "legacy_api_deprecated_at: must be a date in format YYYY-MM-DD, got %q", c.LegacyAPI.DeprecatedAt)
+	sunsetAt, sunsetErr := time.Parse(DateLayout, c.LegacyAPI.SunsetAt)
+	check(sunsetErr == nil, "legacy_api_sunset_at: must be a date in format YYYY-MM-DD, got %q", c.LegacyAPI.SunsetAt)
+	check(err != nil || sunsetErr != nil || sunsetAt.After(deprecatedAt),
+		"legacy_api_sunset_at: must be after legacy_api_deprecated_at %s, got %s", c.LegacyAPI.DeprecatedAt, c.LegacyAPI.SunsetAt)
+	check(strings.HasPrefix(c.Gateway.Prefix, "/"), "gateway_prefix: must start with /, got %q", c.Gateway.Prefix)
+	check(strings.HasPrefix(c.Connect.Prefix, "/"), "connect_prefix: must start with /, got %q", c.Connect.Prefix)
+
+	check(contains(tracingExporters, c.Tracing.Exporter), "tracing_exporter: must be one of %s, got %q",
+		strings.Join(tracingExporters, ", "), c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"tracing_sample_ratio: must be in range 0-1, got %v", c.Tracing.SampleRatio)
+
+	check(validPort(c.DB.Port), "db_port: must be in range 1-65535, got %d", c.DB.Port)
+	check(c.DB.Password != "", "db_password: must not be empty")
+	check(c.Migrate.LockTimeout > 0, "migrate_lock_timeout: must be positive, got %s", c.Migrate.LockTimeout)
+	check(c.Export.Dir != "", "export_dir: must not be empty")
+
+	return errors.Join(errs...)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/app"
+)
+
+// configCheckCommand проверяет параметры. Ошибки загрузки и проверки сообщаются до выполнения команды,
+// поэтому здесь остается только проверить подключение к БД, если оно запрошено.
+type configCheckCommand struct {
+	cfg *config.Config
+
+	Connect bool `long:"connect" description:"Also connect to the database and compare schema version"`
+}
+
+func (c *configCheckCommand) Execute([]string) error {
+	if c.Connect {
+		ctx, cancel := commandContext()
+		defer cancel()
+
+		conn, err := openDB(ctx, c.cfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		migrator, err := app.NewMigrator(ctx, conn, c.cfg.DB.Name)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		if err := printVersion(migrator); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("config is valid")
+	return nil
+}
@@ -1,19 +1,16 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"go-test-grpc-http/cmd/go-test-grpc-http/config"
-	"go-test-grpc-http/internal/app"
 	"log"
-	"sync"
+	"os"
 
 	_ "go-test-grpc-http/docs"
 
+	"github.com/jessevdk/go-flags"
 	_ "github.com/lib/pq"
 	_ "github.com/swaggo/http-swagger"
-	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
 type appVersion struct {
@@ -44,9 +41,9 @@ var AppVersion *appVersion
 // @description API for Golang Test Project
 // @termsOfService http://swagger.io/terms/
 // @contact.name Invar Poyda
-// @version 0.0.1
+// @version 1.0
 // @host localhost:8001
-// @basePath /api/v0.0.1
+// @basePath /api/v1
 // @schemes http
 
 // @securitydefinitions.apikey JwtAuth
@@ -54,73 +51,42 @@ var AppVersion *appVersion
 // @name Authorization
 // @description JWT Bearer токен для аутентификации
 func main() {
-	// Parse the application configuration
-	cfg, err := config.GetAppConfig()
-	if err != nil {
-		log.Fatalf("can't parse app config: %v", err)
+	// Parse the application configuration and subcommand
+	cfg := &config.Config{}
+	parser := config.NewParser(cfg)
+	if err := addCommands(parser, cfg); err != nil {
+		log.Fatalf("can't init commands: %v", err)
 	}
 
-	AppVersion = &appVersion{}
-	AppVersion.LoadFromConfig(cfg)
-
-	fmt.Println("Parsed config:", cfg)
+	// Подкоманда выполняется после проверки параметров, без подкоманды запускается сервер
+	var command flags.Commander = &serveCommand{cfg: cfg}
+	var commandArgs []string
+	parser.CommandHandler = func(active flags.Commander, args []string) error {
+		if active != nil {
+			command = active
+		}
+		commandArgs = args
+		return nil
+	}
 
-	// Initialize the logger
-	logConfig := zap.NewProductionConfig()
-	logConfig.Development = cfg.Debug
-	level, err := zapcore.ParseLevel(cfg.LogLevel)
-	if err != nil {
-		log.Fatalf("invalid log level: %v", err)
+	err := config.Parse(parser, cfg, os.Args[1:])
+	if flags.WroteHelp(err) {
 		return
 	}
-	logConfig.Level = zap.NewAtomicLevelAt(level)
-	logConfig.OutputPaths = []string{cfg.PathLog}
-
-	logger, err := logConfig.Build()
 	if err != nil {
-		log.Fatalf("can't create logger: %v", err)
-		return
+		log.Fatalf("can't parse app config: %v", err)
 	}
+	config.SetAppConfig(cfg)
 
-	defer logger.Sync()
-
-	defer func() {
-		if e := recover(); e != nil {
-			logger.Fatal("panic error", zap.Error(fmt.Errorf("%s", e)))
-		}
-	}()
-
-	wg := &sync.WaitGroup{}
-	ctx, cancelCtx := context.WithCancel(context.Background())
-	defer cancelCtx()
-
-	application := app.NewApp(cfg, logger)
-	logger.Info("starting application", zap.String("version", AppVersion.GetRelease()))
-	// Запуск приложения
-	wg.Add(1)
-	go func() {
-		defer func() {
-			if e := recover(); e != nil {
-				logger.Panic("application start panic", zap.Error(fmt.Errorf("%s", e)))
-			}
-			wg.Done()
-		}()
-		application.Start(ctx)
-	}()
-
-	// Ожидание завершения контекста для graceful shutdown
-	wg.Add(1)
-	go func() {
-		defer func() {
-			wg.Done()
-		}()
-		<-ctx.Done()
-		err := application.GracefulShutdown(ctx)
-		if err != nil {
-			logger.Fatal("graceful shutdown error", zap.Error(err))
+	// Вывод действующей конфигурации без запуска приложения
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatalf("can't print config: %v", err)
 		}
-	}()
-	wg.Wait()
+		return
+	}
 
-	logger.Warn("application is shutdown")
+	if err := command.Execute(commandArgs); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/app"
+)
+
+type migrateUpCommand struct {
+	cfg *config.Config
+}
+
+func (m *migrateUpCommand) Execute([]string) error {
+	return withMigrator(m.cfg, func(migrator *app.Migrator) error {
+		return migrator.Up()
+	})
+}
+
+type migrateDownCommand struct {
+	cfg *config.Config
+
+	Steps int `long:"steps" description:"Number of migrations to roll back" default:"1"`
+}
+
+func (m *migrateDownCommand) Execute([]string) error {
+	return withMigrator(m.cfg, func(migrator *app.Migrator) error {
+		return migrator.Down(m.Steps)
+	})
+}
+
+type migrateGotoCommand struct {
+	cfg *config.Config
+
+	Args struct {
+		Version uint `positional-arg-name:"version" description:"Target schema version"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (m *migrateGotoCommand) Execute([]string) error {
+	return withMigrator(m.cfg, func(migrator *app.Migrator) error {
+		return migrator.Goto(m.Args.Version)
+	})
+}
+
+type migrateVersionCommand struct {
+	cfg *config.Config
+}
+
+func (m *migrateVersionCommand) Execute([]string) error {
+	return withMigrator(m.cfg, func(migrator *app.Migrator) error {
+		return nil
+	})
+}
+
+type migrateForceCommand struct {
+	cfg *config.Config
+
+	Args struct {
+		Version int `positional-arg-name:"version" description:"Schema version to record, -1 for empty schema"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (m *migrateForceCommand) Execute([]string) error {
+	return withMigrator(m.cfg, func(migrator *app.Migrator) error {
+		return migrator.Force(m.Args.Version)
+	})
+}
+
+// withMigrator выполняет fn и печатает версию схемы после нее
+func withMigrator(cfg *config.Config, fn func(migrator *app.Migrator) error) error {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	conn, err := openDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	migrator, err := app.NewMigrator(ctx, conn, cfg.DB.Name)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	// Ожидание реплик, которые применяют миграции при запуске
+	lockCtx, cancelLock := context.WithTimeout(ctx, cfg.Migrate.LockTimeout)
+	defer cancelLock()
+	if err := migrator.Lock(lockCtx); err != nil {
+		return err
+	}
+	defer migrator.Unlock()
+
+	// Прерывание по сигналу завершает работу после текущей миграции
+	go func() {
+		<-ctx.Done()
+		migrator.Stop()
+	}()
+
+	if err := fn(migrator); err != nil {
+		return err
+	}
+
+	return printVersion(migrator)
+}
+
+func printVersion(migrator *app.Migrator) error {
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	latest, err := migrator.Latest()
+	if err != nil {
+		return err
+	}
+
+	state := "clean"
+	if dirty {
+		state = "dirty"
+	}
+	fmt.Printf("schema version %d (%s), latest %d\n", version, state, latest)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/app"
+	"go-test-grpc-http/internal/lifecycle"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// serveCommand запускает HTTP- и gRPC-серверы до получения SIGINT/SIGTERM
+type serveCommand struct {
+	cfg *config.Config
+}
+
+func (s *serveCommand) Execute([]string) error {
+	cfg := s.cfg
+
+	AppVersion = &appVersion{}
+	AppVersion.LoadFromConfig(cfg)
+
+	// Initialize the logger
+	logConfig := zap.NewProductionConfig()
+	logConfig.Development = cfg.Debug
+	level, err := zapcore.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	logConfig.Level = zap.NewAtomicLevelAt(level)
+	logConfig.OutputPaths = []string{cfg.PathLog}
+
+	logger, err := logConfig.Build()
+	if err != nil {
+		return fmt.Errorf("can't create logger: %w", err)
+	}
+
+	defer logger.Sync()
+
+	// Параметры выводятся в том же виде, что и --print-config: секреты скрыты
+	logger.Info("parsed config", zap.Stringer("config", cfg))
+
+	defer func() {
+		if e := recover(); e != nil {
+			logger.Fatal("panic error", zap.Error(fmt.Errorf("%s", e)))
+		}
+	}()
+
+	// Контекст отменяется по SIGINT/SIGTERM
+	ctx, cancelCtx := lifecycle.WithSignals(context.Background(), logger)
+	defer cancelCtx()
+
+	application := app.NewApp(cfg, logger, logConfig.Level)
+	logger.Info("starting application", zap.String("version", AppVersion.GetRelease()))
+	// Запуск приложения
+	startErr := application.Start(ctx)
+	if startErr != nil {
+		logger.Error("application start error", zap.Error(startErr))
+	}
+
+	// Ожидание сигнала или остановки одного из серверов для graceful shutdown.
+	// Контекст остановки не связан с ctx, который к этому моменту уже отменен.
+	<-application.Done()
+	err = application.GracefulShutdown(context.Background())
+	if err != nil {
+		logger.Fatal("graceful shutdown error", zap.Error(err))
+	}
+	if startErr != nil {
+		logger.Fatal("application is not started")
+	}
+
+	logger.Warn("application is shutdown")
+	return nil
+}
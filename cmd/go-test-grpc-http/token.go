@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+)
+
+// tokenIssueCommand выпускает JWT так же, как вход по email: заблокированным пользователям токен не выдается
+type tokenIssueCommand struct {
+	cfg *config.Config
+	userRef
+}
+
+func (t *tokenIssueCommand) Execute([]string) error {
+	return withUsers(t.cfg, func(ctx context.Context, interactor usecase.UserInteractor) error {
+		email := t.Email
+		if email == "" {
+			id, err := t.resolve(ctx, interactor)
+			if err != nil {
+				return err
+			}
+			user, err := interactor.GetById(ctx, id)
+			if err != nil {
+				return err
+			}
+			email = user.Email
+		}
+
+		id, err := interactor.SignIn(ctx, email)
+		if err != nil {
+			return err
+		}
+		token, err := entity.GenerateToken(id).String()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(token)
+		return nil
+	})
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+)
+
+// userRef пользователь, к которому применяется команда: по id или по email
+type userRef struct {
+	ID    string `long:"id" description:"User ID"`
+	Email string `long:"email" description:"User email"`
+}
+
+func (r *userRef) resolve(ctx context.Context, interactor usecase.UserInteractor) (*entity.UserID, error) {
+	switch {
+	case r.ID != "" && r.Email != "":
+		return nil, errors.New("only one of --id and --email can be set")
+	case r.ID != "":
+		var id entity.UserID
+		if err := id.FromString(r.ID); err != nil {
+			return nil, fmt.Errorf("invalid user id: %w", err)
+		}
+		return &id, nil
+	case r.Email != "":
+		return interactor.GetIdByEmail(ctx, r.Email)
+	}
+
+	return nil, errors.New("--id or --email is required")
+}
+
+type userCreateCommand struct {
+	cfg *config.Config
+
+	Email      string `long:"email" description:"Email" required:"true"`
+	Password   string `long:"password" description:"Password, generated and printed if empty"`
+	FirstName  string `long:"first-name" description:"First name" required:"true"`
+	LastName   string `long:"last-name" description:"Last name" required:"true"`
+	SecondName string `long:"second-name" description:"Second name"`
+	Age        int    `long:"age" description:"Age"`
+	Phone      string `long:"phone" description:"Phone in E.164 format"`
+	Role       string `long:"role" description:"Role" choice:"user" choice:"admin" default:"user"`
+}
+
+func (u *userCreateCommand) Execute([]string) error {
+	return withUsers(u.cfg, func(ctx context.Context, interactor usecase.UserInteractor) error {
+		password, generated, err := passwordOrRandom(u.Password)
+		if err != nil {
+			return err
+		}
+
+		// Роль задается при создании, чтобы не оставить пользователя с ролью по умолчанию при ошибке
+		id, err := interactor.Create(ctx, &entity.UserCreate{
+			FirstName:  u.FirstName,
+			SecondName: u.SecondName,
+			LastName:   u.LastName,
+			Password:   password,
+			Age:        u.Age,
+			Email:      u.Email,
+			Phone:      u.Phone,
+			Role:       entity.Role(u.Role),
+		})
+		if err != nil {
+			return err
+		}
+		user, err := interactor.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		printUser(user)
+		if generated {
+			fmt.Printf("password: %s\n", password)
+		}
+		return nil
+	})
+}
+
+type userSetRoleCommand struct {
+	cfg *config.Config
+	userRef
+
+	Role string `long:"role" description:"New role" choice:"user" choice:"admin" required:"true"`
+}
+
+func (u *userSetRoleCommand) Execute([]string) error {
+	return withUsers(u.cfg, func(ctx context.Context, interactor usecase.UserInteractor) error {
+		id, err := u.resolve(ctx, interactor)
+		if err != nil {
+			return err
+		}
+		user, err := interactor.SetRole(ctx, id, entity.Role(u.Role))
+		if err != nil {
+			return err
+		}
+
+		printUser(user)
+		return nil
+	})
+}
+
+type userDisableCommand struct {
+	cfg *config.Config
+	userRef
+
+	Enable bool `long:"enable" description:"Remove the block instead"`
+}
+
+func (u *userDisableCommand) Execute([]string) error {
+	return withUsers(u.cfg, func(ctx context.Context, interactor usecase.UserInteractor) error {
+		id, err := u.resolve(ctx, interactor)
+		if err != nil {
+			return err
+		}
+		user, err := interactor.SetDisabled(ctx, id, !u.Enable)
+		if err != nil {
+			return err
+		}
+
+		printUser(user)
+		return nil
+	})
+}
+
+// userResetPasswordCommand сохраняет новый пароль пользователя.
+// Вход пароль пока не проверяет, поэтому команда не закрывает доступ к учетной записи.
+type userResetPasswordCommand struct {
+	cfg *config.Config
+	userRef
+
+	Password string `long:"password" description:"New password, generated and printed if empty"`
+}
+
+func (u *userResetPasswordCommand) Execute([]string) error {
+	return withUsers(u.cfg, func(ctx context.Context, interactor usecase.UserInteractor) error {
+		id, err := u.resolve(ctx, interactor)
+		if err != nil {
+			return err
+		}
+		password, generated, err := passwordOrRandom(u.Password)
+		if err != nil {
+			return err
+		}
+		user, err := interactor.ResetPassword(ctx, id, password)
+		if err != nil {
+			return err
+		}
+
+		printUser(user)
+		if generated {
+			fmt.Printf("password: %s\n", password)
+		}
+		return nil
+	})
+}
+
+// withUsers выполняет fn со сценариями использования пользователей
+func withUsers(cfg *config.Config, fn func(ctx context.Context, interactor usecase.UserInteractor) error) error {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	interactor, closeDB, err := openUsers(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	return fn(ctx, interactor)
+}
+
+// passwordOrRandom возвращает password или, если он пуст, случайный пароль
+func passwordOrRandom(password string) (string, bool, error) {
+	if password != "" {
+		return password, false, nil
+	}
+
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, fmt.Errorf("can't generate password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), true, nil
+}
+
+func printUser(user *entity.User) {
+	state := "active"
+	if user.DisabledAt != nil {
+		state = "disabled since " + user.DisabledAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	fmt.Printf("id: %s\nemail: %s\nrole: %s\nstate: %s\n", user.ID, user.Email, user.Role, state)
+}
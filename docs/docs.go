@@ -19,166 +19,283 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/signin": {
-            "post": {
-                "description": "Авторизация пользователя с использованием email и пароля.",
-                "consumes": [
-                    "application/json"
+        "/admin/users/export": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
                 ],
+                "description": "Потоковая выгрузка пользователей в CSV или NDJSON (chunked transfer). Пароль не выгружается.\nДоступно только администраторам.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Вход пользователя",
+                "summary": "Выгрузка пользователей",
                 "parameters": [
                     {
-                        "description": "Данные пользователя для входа",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.UserSignIn"
-                        }
+                        "type": "string",
+                        "description": "Формат данных: csv (по умолчанию), ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую: id, first_name, second_name, last_name, age, email, phone, role",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока электронной почты",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока имени, фамилии или отчества",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Роль: user, admin",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальный возраст",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальный возраст",
+                        "name": "max_age",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Токен авторизации",
+                        "description": "Файл выгрузки",
                         "schema": {
-                            "$ref": "#/definitions/view.TokenView"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос"
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "401": {
-                        "description": "Ошибка авторизации"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Некорректные параметры выгрузки",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
             }
         },
-        "/auth/signup": {
+        "/admin/users/export/jobs": {
             "post": {
-                "description": "Регистрация нового пользователя.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
                 ],
+                "description": "Запускает выгрузку пользователей в файл на сервере. Параметры совпадают с синхронной выгрузкой.\nДоступно только администраторам.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Регистрация пользователя",
+                "summary": "Запуск фоновой выгрузки пользователей",
                 "parameters": [
                     {
-                        "description": "Данные пользователя для регистрации",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
-                        }
+                        "type": "string",
+                        "description": "Формат данных: csv (по умолчанию), ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую: id, first_name, second_name, last_name, age, email, phone, role",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока электронной почты",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подстрока имени, фамилии или отчества",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Роль: user, admin",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальный возраст",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальный возраст",
+                        "name": "max_age",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Токен авторизации",
+                    "202": {
+                        "description": "Задача выгрузки",
                         "schema": {
-                            "$ref": "#/definitions/view.TokenView"
+                            "$ref": "#/definitions/view.ExportJobView"
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос"
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Некорректные параметры выгрузки",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
             }
         },
-        "/users/email/{email}": {
+        "/admin/users/export/jobs/{id}": {
             "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение информации о пользователе по его уникальному идентификатору.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает состояние задачи выгрузки. Доступно только администраторам.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Получение пользователя по Email",
+                "summary": "Статус фоновой выгрузки пользователей",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email пользователя",
-                        "name": "email",
+                        "description": "ID задачи",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Задача выгрузки",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "$ref": "#/definitions/view.ExportJobView"
                         }
                     },
-                    "400": {
-                        "description": "Некорректный запрос"
-                    },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
             }
         },
-        "/users/id/{id}": {
+        "/admin/users/export/jobs/{id}/file": {
             "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение информации о пользователе по его уникальному идентификатору.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает файл завершенной задачи выгрузки. Доступно только администраторам.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Получение пользователя по ID",
+                "summary": "Скачивание результата фоновой выгрузки",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "description": "ID задачи",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -186,42 +303,59 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Файл выгрузки",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Некорректный запрос"
-                    },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "409": {
+                        "description": "Выгрузка не завершена",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/id/{id}/erase": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Обновление информации о пользователе по его уникальному идентификатору.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Обезличивает пользователя по запросу на удаление данных: персональные данные в профиле и истории\nизменений затираются, записи и связи между ними сохраняются. Доступно только администраторам.",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Обновление пользователя по ID",
+                "summary": "Удаление персональных данных пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -229,58 +363,60 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные пользователя для обновления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Обновленные данные пользователя",
+                    "204": {
+                        "description": "Персональные данные удалены"
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "$ref": "#/definitions/view.ProblemView"
                         }
                     },
-                    "400": {
-                        "description": "Некорректный запрос"
-                    },
-                    "401": {
-                        "description": "Неавторизованный запрос"
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/id/{id}/export": {
+            "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Удаление пользователя по его уникальному идентификатору.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает JSON-архив со всеми данными, хранимыми о пользователе: профиль, история изменений\nи действия пользователя. Список sessions всегда пуст: записи о сеансах не ведутся.\nДоступно только администраторам.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Удаление пользователя по ID",
+                "summary": "Выгрузка всех данных пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -291,60 +427,664 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Пользователь успешно удален"
-                    },
-                    "400": {
-                        "description": "Некорректный запрос"
+                    "200": {
+                        "description": "Архив данных пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserDataArchiveView"
+                        }
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
             }
         },
-        "/users/me": {
-            "get": {
+        "/admin/users/import": {
+            "post": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Получение пользователя по его уникальному идентификатору из JWT токена",
+                "description": "Импорт пользователей из CSV (с заголовком) или NDJSON. Каждая строка проверяется отдельно,\nв ответе возвращается результат по каждой строке. Доступно только администраторам.",
                 "consumes": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
+                ],
+                "summary": "Массовый импорт пользователей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат данных: csv, ndjson. По умолчанию определяется по Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поведение при ошибках: all-or-nothing (по умолчанию), best-effort",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Проверить данные без сохранения",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество строк в одной транзакции",
+                        "name": "batch_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Файл импорта",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 ],
-                "summary": "Получение пользователя по JWT токену",
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
+                        "description": "Отчет об импорте",
                         "schema": {
-                            "$ref": "#/definitions/view.UserView"
+                            "$ref": "#/definitions/view.UserImportReportView"
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос"
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
-                    "404": {
-                        "description": "Пользователь не найден"
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/signin": {
+            "post": {
+                "description": "Авторизация пользователя с использованием email и пароля.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Вход пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя для входа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/view.SignInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Токен авторизации",
+                        "schema": {
+                            "$ref": "#/definitions/view.TokenView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Ошибка авторизации",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь заблокирован",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большое тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/signup": {
+            "post": {
+                "description": "Регистрация нового пользователя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Регистрация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя для регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/view.UserCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Токен авторизации",
+                        "schema": {
+                            "$ref": "#/definitions/view.TokenView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "409": {
+                        "description": "Email уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большое тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/email/{email}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение информации о пользователе по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получение пользователя по Email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email пользователя",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/id/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение информации о пользователе по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получение пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Обновление информации о пользователе по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Обновление пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные пользователя для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/view.UserCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленные данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "409": {
+                        "description": "Email уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большое тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление пользователя по его уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удаление пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Пользователь успешно удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/id/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Постраничное получение истории создания, изменения и удаления пользователя, от новых записей к старым.\nДля следующей страницы передайте next_cursor из предыдущего ответа.\nДоступно самому пользователю и администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "История изменений пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный идентификатор пользователя (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Курсор страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "История пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserHistoryView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Получение пользователя по его уникальному идентификатору из JWT токена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получение пользователя по JWT токену",
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserView"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
             },
@@ -372,7 +1112,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserCreate"
+                            "$ref": "#/definitions/view.UserCreateRequest"
                         }
                     }
                 ],
@@ -384,111 +1124,595 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос"
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "409": {
+                        "description": "Email уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "413": {
+                        "description": "Слишком большое тело запроса",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка при обработке данных",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Удаление пользователя по его уникальному идентификатору из JWT токена.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удаление пользователя по JWT токену",
+                "responses": {
+                    "204": {
+                        "description": "Пользователь успешно удален"
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/erase": {
+            "post": {
+                "security": [
+                    {
+                        "JwtAuth": []
+                    }
+                ],
+                "description": "Обезличивает пользователя: персональные данные в профиле и истории изменений затираются,\nзаписи и связи между ними сохраняются. Войти под пользователем после этого нельзя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удаление персональных данных пользователя по JWT токену",
+                "responses": {
+                    "204": {
+                        "description": "Персональные данные удалены"
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "404": {
-                        "description": "Пользователь не найден"
-                    },
-                    "422": {
-                        "description": "Ошибка при обработке данных"
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/users/me/export": {
+            "get": {
                 "security": [
                     {
                         "JwtAuth": []
                     }
                 ],
-                "description": "Удаление пользователя по его уникальному идентификатору из JWT токена.",
+                "description": "Возвращает JSON-архив со всеми данными, хранимыми о пользователе: профиль, история изменений\nи действия пользователя. Пароль в архив не включается. Список sessions всегда пуст: токены доступа (JWT)\nне хранят состояния, и записи о сеансах не ведутся.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
                     "Users"
                 ],
-                "summary": "Удаление пользователя по JWT токену",
+                "summary": "Выгрузка всех данных пользователя по JWT токену",
                 "responses": {
-                    "204": {
-                        "description": "Пользователь успешно удален"
-                    },
-                    "400": {
-                        "description": "Некорректный запрос"
+                    "200": {
+                        "description": "Архив данных пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/view.UserDataArchiveView"
+                        }
                     },
                     "401": {
-                        "description": "Неавторизованный запрос"
+                        "description": "Неавторизованный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "404": {
-                        "description": "Пользователь не найден"
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера"
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/view.ProblemView"
+                        }
                     }
                 }
             }
         }
     },
     "definitions": {
-        "entity.UserCreate": {
+        "view.ExportJobView": {
             "type": "object",
             "properties": {
-                "age": {
-                    "description": "Возраст",
+                "columns": {
+                    "description": "Экспортируемые колонки",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "description": "Время создания",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Описание ошибки",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "description": "Время завершения",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Формат файла: csv, ndjson",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID задачи",
+                    "type": "string"
+                },
+                "rows": {
+                    "description": "Количество выгруженных пользователей",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Статус: running, done, failed",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ProblemView": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "Описание конкретной ошибки",
+                    "type": "string"
+                },
+                "instance": {
+                    "description": "Путь запроса, при обработке которого возникла ошибка",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "ID запроса для поиска в логах",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "HTTP-статус",
                     "type": "integer"
                 },
+                "title": {
+                    "description": "Краткое описание вида ошибки",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "URI вида ошибки, about:blank для ошибок без вида",
+                    "type": "string"
+                },
+                "violations": {
+                    "description": "Нарушения ограничений по полям",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.ViolationView"
+                    }
+                }
+            }
+        },
+        "view.SignInRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
                 "email": {
                     "description": "Электронная почта",
                     "type": "string"
                 },
+                "password": {
+                    "description": "Пароль",
+                    "type": "string"
+                }
+            }
+        },
+        "view.TokenView": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "JWT токен",
+                    "type": "string"
+                }
+            }
+        },
+        "view.UserCreateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "firstName",
+                "lastName",
+                "password"
+            ],
+            "properties": {
+                "age": {
+                    "description": "Возраст",
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 0
+                },
+                "email": {
+                    "description": "Электронная почта",
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "firstName": {
                     "description": "Имя",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 50
                 },
                 "lastName": {
                     "description": "Фамилия",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 50
                 },
                 "password": {
                     "description": "Пароль",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "phone": {
-                    "description": "Номер телефона",
+                    "description": "Номер телефона в формате E.164",
                     "type": "string"
                 },
                 "secondName": {
                     "description": "Отчество",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "view.UserDataArchiveView": {
+            "type": "object",
+            "properties": {
+                "audit": {
+                    "description": "Действия пользователя над другими записями",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.UserHistoryEntryView"
+                    }
+                },
+                "exported_at": {
+                    "description": "Время формирования архива",
+                    "type": "string"
+                },
+                "history": {
+                    "description": "История изменений профиля",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.UserHistoryEntryView"
+                    }
+                },
+                "profile": {
+                    "description": "Профиль",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.UserProfileView"
+                        }
+                    ]
+                },
+                "sessions": {
+                    "description": "Сеансы, всегда пусто: JWT не хранятся на сервере",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.UserSessionView"
+                    }
+                }
+            }
+        },
+        "view.UserHistoryEntryView": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Действие: create, update, delete",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "ID пользователя, выполнившего действие",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "Данные после изменения",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.UserSnapshotView"
+                        }
+                    ]
+                },
+                "before": {
+                    "description": "Данные до изменения",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/view.UserSnapshotView"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "description": "Время изменения",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID записи",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "ID пользователя, к которому относится запись",
+                    "type": "string"
+                }
+            }
+        },
+        "view.UserHistoryView": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "description": "Записи от новых к старым",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.UserHistoryEntryView"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Курсор следующей страницы",
+                    "type": "integer"
+                }
+            }
+        },
+        "view.UserImportReportView": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "description": "Изменения сохранены в бд",
+                    "type": "boolean"
+                },
+                "created": {
+                    "description": "Создано пользователей",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "description": "Импорт выполнен без сохранения",
+                    "type": "boolean"
+                },
+                "failed": {
+                    "description": "Строк с ошибками",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Поведение при ошибках: all-or-nothing, best-effort",
                     "type": "string"
+                },
+                "results": {
+                    "description": "Результаты по строкам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/view.UserImportResultView"
+                    }
+                },
+                "total": {
+                    "description": "Всего строк",
+                    "type": "integer"
                 }
             }
         },
-        "entity.UserSignIn": {
+        "view.UserImportResultView": {
             "type": "object",
             "properties": {
                 "email": {
                     "description": "Электронная почта",
                     "type": "string"
                 },
-                "password": {
-                    "description": "Пароль",
+                "error": {
+                    "description": "Описание ошибки",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID созданного пользователя",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "Номер строки во входных данных",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Статус строки: created, valid, failed, rolled_back",
                     "type": "string"
                 }
             }
         },
-        "view.TokenView": {
+        "view.UserProfileView": {
             "type": "object",
             "properties": {
-                "token": {
-                    "description": "JWT токен",
+                "age": {
+                    "description": "Возраст",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "Время создания",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Электронная почта",
+                    "type": "string"
+                },
+                "erased_at": {
+                    "description": "Время удаления персональных данных",
+                    "type": "string"
+                },
+                "first_name": {
+                    "description": "Имя",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID",
+                    "type": "string"
+                },
+                "last_name": {
+                    "description": "Фамилия",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "Номер мобильного телефона",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Роль",
+                    "type": "string"
+                },
+                "second_name": {
+                    "description": "Отчество",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Время последнего изменения",
+                    "type": "string"
+                }
+            }
+        },
+        "view.UserSessionView": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "Время окончания действия",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID токена",
+                    "type": "string"
+                },
+                "issued_at": {
+                    "description": "Время выдачи",
+                    "type": "string"
+                }
+            }
+        },
+        "view.UserSnapshotView": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "Возраст",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "Время создания",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Электронная почта",
+                    "type": "string"
+                },
+                "first_name": {
+                    "description": "Имя",
+                    "type": "string"
+                },
+                "last_name": {
+                    "description": "Фамилия",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "Номер мобильного телефона",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Роль",
+                    "type": "string"
+                },
+                "second_name": {
+                    "description": "Отчество",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Время последнего изменения",
                     "type": "string"
                 }
             }
@@ -500,10 +1724,18 @@ const docTemplate = `{
                     "description": "Возраст",
                     "type": "integer"
                 },
+                "created_at": {
+                    "description": "Время создания",
+                    "type": "string"
+                },
                 "email": {
                     "description": "Электронная почта",
                     "type": "string"
                 },
+                "erased_at": {
+                    "description": "Время удаления персональных данных",
+                    "type": "string"
+                },
                 "id": {
                     "description": "ID",
                     "type": "string"
@@ -515,6 +1747,27 @@ const docTemplate = `{
                 "phone": {
                     "description": "Номер мобильного телефона",
                     "type": "string"
+                },
+                "role": {
+                    "description": "Роль",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Время последнего изменения",
+                    "type": "string"
+                }
+            }
+        },
+        "view.ViolationView": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Путь к полю",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Описание нарушения",
+                    "type": "string"
                 }
             }
         }
@@ -531,9 +1784,9 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "0.0.1",
+	Version:          "1.0",
 	Host:             "localhost:8001",
-	BasePath:         "/api/v0.0.1",
+	BasePath:         "/api/v1",
 	Schemes:          []string{"http"},
 	Title:            "Golang Test API",
 	Description:      "API for Golang Test Project",
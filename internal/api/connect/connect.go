@@ -0,0 +1,71 @@
+package connect
+
+import (
+	"context"
+	"errors"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1/userv1connect"
+	"net/http"
+
+	connect_go "github.com/bufbuild/connect-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Заголовки запроса Connect, передаваемые в метаданные gRPC
+var forwardedHeaders = []string{
+	"Authorization",
+	"Accept-Language",
+	"X-Request-ID",
+}
+
+// NewHandler создает обработчик протокола Connect для сервисов UserAPI и AuthAPI.
+// Запросы передаются gRPC-серверу через conn и проходят его перехватчики
+// (авторизация, проверка, логирование, локализация ошибок).
+func NewHandler(conn *grpc.ClientConn) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(userv1connect.NewUserAPIHandler(&userAPI{client: userv1.NewUserAPIClient(conn)}))
+	mux.Handle(userv1connect.NewAuthAPIHandler(&authAPI{client: userv1.NewAuthAPIClient(conn)}))
+
+	return mux
+}
+
+// forward вызывает метод gRPC-сервера с сообщением и заголовками запроса Connect
+func forward[Req, Res any](
+	ctx context.Context,
+	request *connect_go.Request[Req],
+	call func(context.Context, *Req, ...grpc.CallOption) (*Res, error),
+) (*connect_go.Response[Res], error) {
+	md := metadata.MD{}
+	for _, key := range forwardedHeaders {
+		if values := request.Header().Values(key); len(values) > 0 {
+			md.Set(key, values...)
+		}
+	}
+
+	res, err := call(metadata.NewOutgoingContext(ctx, md), request.Msg)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	return connect_go.NewResponse(res), nil
+}
+
+// fromStatus переводит ошибку gRPC в ошибку Connect с тем же кодом, сообщением и деталями
+func fromStatus(err error) error {
+	st := status.Convert(err)
+	connectErr := connect_go.NewError(connect_go.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Details() {
+		msg, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+		if errDetail, err := connect_go.NewErrorDetail(msg); err == nil {
+			connectErr.AddDetail(errDetail)
+		}
+	}
+
+	return connectErr
+}
@@ -0,0 +1,189 @@
+package connect
+
+import (
+	"context"
+	"errors"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1/userv1connect"
+	"go-test-grpc-http/internal/api/grpc/middleware"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	connect_go "github.com/bufbuild/connect-go"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type stubAuthServer struct {
+	userv1.UnimplementedAuthAPIServer
+}
+
+func (s *stubAuthServer) SignIn(ctx context.Context, request *userv1.SignInRequest) (*userv1.SignInResponse, error) {
+	if request.GetEmail() == "" {
+		st, _ := status.New(codes.InvalidArgument, "request is invalid").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "is required"}},
+		})
+		return nil, st.Err()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return &userv1.SignInResponse{
+		Token: strings.Join(md.Get("accept-language"), ","),
+	}, nil
+}
+
+func TestNewHandler(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	userv1.RegisterAuthAPIServer(s, &stubAuthServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	defer conn.Close()
+
+	server := httptest.NewServer(NewHandler(conn))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		client   userv1connect.AuthAPIClient
+		email    string
+		want     string
+		wantCode connect_go.Code
+	}{
+		{
+			name:   "json",
+			client: userv1connect.NewAuthAPIClient(server.Client(), server.URL, connect_go.WithProtoJSON()),
+			email:  "user@example.com",
+			want:   "en-US",
+		},
+		{
+			name:   "binary",
+			client: userv1connect.NewAuthAPIClient(server.Client(), server.URL),
+			email:  "user@example.com",
+			want:   "en-US",
+		},
+		{
+			name:     "error details",
+			client:   userv1connect.NewAuthAPIClient(server.Client(), server.URL, connect_go.WithProtoJSON()),
+			wantCode: connect_go.CodeInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := connect_go.NewRequest(&userv1.SignInRequest{Email: tt.email})
+			request.Header().Set("Accept-Language", "en-US")
+
+			res, err := tt.client.SignIn(context.Background(), request)
+			if tt.wantCode != 0 {
+				var connectErr *connect_go.Error
+				if !errors.As(err, &connectErr) || connectErr.Code() != tt.wantCode {
+					t.Fatalf("SignIn() error = %v, want code %v", err, tt.wantCode)
+				}
+				if len(connectErr.Details()) != 1 {
+					t.Errorf("SignIn() error details = %d, want 1", len(connectErr.Details()))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SignIn() error = %v", err)
+			}
+			if res.Msg.GetToken() != tt.want {
+				t.Errorf("SignIn() token = %q, want %q", res.Msg.GetToken(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHandler_auth(t *testing.T) {
+	config.SetAppConfig(&config.Config{ApiKey: "secret"})
+	token, err := entity.GenerateToken(&entity.UserID{Id: uuid.MustParse("0b8e7c2d-5a4f-4e3b-8c1d-9f6a2e5b7d40")}).String()
+	if err != nil {
+		t.Fatalf("can't generate token: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	interactor := usecase.NewMockUserInteractor(ctrl)
+	interactor.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(&entity.User{}, nil).AnyTimes()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.UnaryInterceptor(middleware.NewAuthMiddleware(interactor)))
+	userv1.RegisterUserAPIServer(s, userv1.UnimplementedUserAPIServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	defer conn.Close()
+
+	server := httptest.NewServer(NewHandler(conn))
+	defer server.Close()
+	client := userv1connect.NewUserAPIClient(server.Client(), server.URL, connect_go.WithProtoJSON())
+
+	const id = "4a6e104d-9d7f-45ff-8de6-37993d709522"
+	update := func(ctx context.Context, authorization string) error {
+		request := connect_go.NewRequest(&userv1.UpdateRequest{Id: id})
+		if authorization != "" {
+			request.Header().Set("Authorization", authorization)
+		}
+		_, err := client.Update(ctx, request)
+		return err
+	}
+	remove := func(ctx context.Context, authorization string) error {
+		request := connect_go.NewRequest(&userv1.DeleteRequest{Id: id})
+		if authorization != "" {
+			request.Header().Set("Authorization", authorization)
+		}
+		_, err := client.Delete(ctx, request)
+		return err
+	}
+
+	tests := []struct {
+		name          string
+		call          func(ctx context.Context, authorization string) error
+		authorization string
+		wantCode      connect_go.Code
+	}{
+		{name: "anonymous update", call: update, wantCode: connect_go.CodeUnauthenticated},
+		{name: "anonymous delete", call: remove, wantCode: connect_go.CodeUnauthenticated},
+		// Запрос с токеном проходит авторизацию и доходит до сервиса
+		{name: "delete with token", call: remove, authorization: "Bearer " + token, wantCode: connect_go.CodeUnimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(context.Background(), tt.authorization)
+			if connect_go.CodeOf(err) != tt.wantCode {
+				t.Errorf("call error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package connect
+
+import (
+	"context"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+
+	connect_go "github.com/bufbuild/connect-go"
+)
+
+type userAPI struct {
+	client userv1.UserAPIClient
+}
+
+func (u *userAPI) GetMe(ctx context.Context, request *connect_go.Request[userv1.GetMeRequest]) (*connect_go.Response[userv1.GetMeResponse], error) {
+	return forward(ctx, request, u.client.GetMe)
+}
+
+func (u *userAPI) UpdateMe(ctx context.Context, request *connect_go.Request[userv1.UpdateMeRequest]) (*connect_go.Response[userv1.UpdateMeResponse], error) {
+	return forward(ctx, request, u.client.UpdateMe)
+}
+
+func (u *userAPI) DeleteMe(ctx context.Context, request *connect_go.Request[userv1.DeleteMeRequest]) (*connect_go.Response[userv1.DeleteMeResponse], error) {
+	return forward(ctx, request, u.client.DeleteMe)
+}
+
+func (u *userAPI) GetById(ctx context.Context, request *connect_go.Request[userv1.GetByIdRequest]) (*connect_go.Response[userv1.GetByIdResponse], error) {
+	return forward(ctx, request, u.client.GetById)
+}
+
+func (u *userAPI) GetByEmail(ctx context.Context, request *connect_go.Request[userv1.GetByEmailRequest]) (*connect_go.Response[userv1.GetByEmailResponse], error) {
+	return forward(ctx, request, u.client.GetByEmail)
+}
+
+func (u *userAPI) Update(ctx context.Context, request *connect_go.Request[userv1.UpdateRequest]) (*connect_go.Response[userv1.UpdateResponse], error) {
+	return forward(ctx, request, u.client.Update)
+}
+
+func (u *userAPI) Delete(ctx context.Context, request *connect_go.Request[userv1.DeleteRequest]) (*connect_go.Response[userv1.DeleteResponse], error) {
+	return forward(ctx, request, u.client.Delete)
+}
+
+func (u *userAPI) GetHistory(ctx context.Context, request *connect_go.Request[userv1.GetHistoryRequest]) (*connect_go.Response[userv1.GetHistoryResponse], error) {
+	return forward(ctx, request, u.client.GetHistory)
+}
+
+type authAPI struct {
+	client userv1.AuthAPIClient
+}
+
+func (a *authAPI) SignUp(ctx context.Context, request *connect_go.Request[userv1.SignUpRequest]) (*connect_go.Response[userv1.SignUpResponse], error) {
+	return forward(ctx, request, a.client.SignUp)
+}
+
+func (a *authAPI) SignIn(ctx context.Context, request *connect_go.Request[userv1.SignInRequest]) (*connect_go.Response[userv1.SignInResponse], error) {
+	return forward(ctx, request, a.client.SignIn)
+}
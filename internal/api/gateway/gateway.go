@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Заголовки HTTP, передаваемые в метаданные gRPC без префикса grpcgateway-
+var forwardedHeaders = map[string]struct{}{
+	"accept-language": {},
+	"x-request-id":    {},
+}
+
+// NewHandler создает HTTP/JSON-шлюз к сервисам UserAPI и AuthAPI, доступным через conn.
+// Маршруты берутся из аннотаций google.api.http в .proto.
+func NewHandler(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames: true,
+			},
+		}),
+	)
+
+	err := userv1.RegisterUserAPIHandler(ctx, mux, conn)
+	if err != nil {
+		return nil, fmt.Errorf("can't register user api gateway: %w", err)
+	}
+	err = userv1.RegisterAuthAPIHandler(ctx, mux, conn)
+	if err != nil {
+		return nil, fmt.Errorf("can't register auth api gateway: %w", err)
+	}
+
+	return mux, nil
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	key = strings.ToLower(key)
+	if _, ok := forwardedHeaders[key]; ok {
+		return key, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
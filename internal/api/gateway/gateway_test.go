@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type stubUserServer struct {
+	userv1.UnimplementedUserAPIServer
+}
+
+func (s *stubUserServer) GetById(ctx context.Context, request *userv1.GetByIdRequest) (*userv1.GetByIdResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "can't find authorization")
+	}
+
+	return &userv1.GetByIdResponse{
+		User: &userv1.User{
+			Id:        request.GetId(),
+			FirstName: strings.Join(md.Get("x-request-id"), ","),
+		},
+	}, nil
+}
+
+func TestNewHandler(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	userv1.RegisterUserAPIServer(s, &stubUserServer{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	defer conn.Close()
+
+	handler, err := NewHandler(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantBody      map[string]any
+	}{
+		{
+			name:          "forwards headers",
+			authorization: "Bearer token",
+			wantStatus:    http.StatusOK,
+			wantBody: map[string]any{
+				"user": map[string]any{"id": "42", "first_name": "request-1"},
+			},
+		},
+		{
+			name:       "maps grpc code",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/42", nil)
+			req.Header.Set("X-Request-ID", "request-1")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantBody == nil {
+				return
+			}
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("can't decode body: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantBody) {
+				t.Errorf("body = %v, want %v", got, tt.wantBody)
+			}
+		})
+	}
+}
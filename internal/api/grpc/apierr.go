@@ -1,7 +1,9 @@
 package grpc
 
 import (
+	"errors"
 	"fmt"
+	"go-test-grpc-http/internal/domain"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -11,12 +13,39 @@ import (
 	"google.golang.org/protobuf/runtime/protoiface"
 )
 
+// ErrorDomain домен ошибок сервиса в errdetails.ErrorInfo
+const ErrorDomain = "servertemplate.user.v1"
+
+var (
+	errInvalidID = domain.InvalidFields("id is invalid", domain.Violation{
+		Field:       "id",
+		Description: "must be a valid UUID",
+	})
+	errNotAuthenticated      = domain.Unauthenticated("user is not authenticated")
+	errHistoryForbidden      = domain.Forbidden("only the user or an admin can view the history")
+	errUserForbidden         = domain.Forbidden("only the user or an admin can change the user")
+	errImportOptionsRequired = domain.InvalidFields("options are required", domain.Violation{
+		Field:       "options",
+		Description: "first message must contain options",
+	})
+)
+
+// invalidField создает ошибку некорректного поля запроса
+func invalidField(field string, err error) error {
+	return domain.InvalidFields(fmt.Sprintf("%s is invalid", field), domain.Violation{
+		Field:       field,
+		Description: err.Error(),
+	})
+}
+
 type apiError struct {
 	Code            codes.Code
 	Message         string
 	Err             *multierror.Error
 	localizeMessage *errdetails.LocalizedMessage
 	Details         []protoiface.MessageV1
+	// messageKey сообщение ошибки предметной области, по которому выбирается перевод из каталога
+	messageKey string
 }
 
 func NewApiError(code codes.Code, msg string, errs ...error) *apiError {
@@ -41,6 +70,26 @@ func NewApiError(code codes.Code, msg string, errs ...error) *apiError {
 	}
 }
 
+// NewDomainApiError создает ошибку, код которой определяется видом ошибки предметной области.
+//
+//	Текст ошибки предметной области добавляется к msg, текст внутренних ошибок в ответ не попадает.
+//	К ошибке прикладываются ErrorInfo с причиной и, если есть нарушения по полям, BadRequest.
+func NewDomainApiError(msg string, err error) *apiError {
+	apiErr := NewApiError(domain.GRPCCode(err), msg, err).
+		WithErrorInfo(domain.Reason(err), nil).
+		WithFieldViolations(domain.ViolationsOf(err)...)
+	if public := domain.PublicMessage(err); public != "" {
+		apiErr.Message = fmt.Sprintf("%s: %s", msg, public)
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		apiErr.messageKey = domainErr.Error()
+	}
+
+	return apiErr
+}
+
 func (e *apiError) Error() string {
 	if e.Message != "" && e.Err != nil {
 		return fmt.Sprintf("%s: %s", e.Message, e.Err)
@@ -76,9 +125,9 @@ func (e *apiError) GRPCStatus() *status.Status {
 		details = append(details, e.Details...)
 	}
 	if len(details) > 0 {
-		var err error
-		statusErr, err = statusErr.WithDetails(details...)
-		_ = err
+		if withDetails, err := statusErr.WithDetails(details...); err == nil {
+			statusErr = withDetails
+		}
 	}
 
 	return statusErr
@@ -89,14 +138,40 @@ func (e *apiError) WithDetails(details ...protoiface.MessageV1) *apiError {
 	return e
 }
 
-func (e *apiError) WithLocalizedMessage(msg string) *apiError {
+func (e *apiError) WithLocalizedMessage(locale, msg string) *apiError {
 	if msg == "" {
 		return e
 	}
 	e.localizeMessage = &errdetails.LocalizedMessage{
-		Locale:  "ru-RU",
+		Locale:  locale,
 		Message: msg,
 	}
 
 	return e
 }
+
+// WithErrorInfo прикладывает причину ошибки, по которой клиент может обработать ее программно
+func (e *apiError) WithErrorInfo(reason string, metadata map[string]string) *apiError {
+	return e.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+}
+
+// WithFieldViolations прикладывает нарушения ограничений по полям запроса
+func (e *apiError) WithFieldViolations(violations ...domain.Violation) *apiError {
+	if len(violations) == 0 {
+		return e
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+
+	return e.WithDetails(badRequest)
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/domain"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLocaleFromContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage []string
+		want           string
+	}{
+		{
+			name: "no metadata",
+			want: LocaleRU,
+		},
+		{
+			name:           "exact match",
+			acceptLanguage: []string{"en-US"},
+			want:           LocaleEN,
+		},
+		{
+			name:           "weighted list",
+			acceptLanguage: []string{"de-DE,en;q=0.8,ru;q=0.5"},
+			want:           LocaleEN,
+		},
+		{
+			name:           "unsupported language",
+			acceptLanguage: []string{"ja-JP"},
+			want:           LocaleRU,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.acceptLanguage != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(acceptLanguageKey, tt.acceptLanguage[0]))
+			}
+
+			if got := localeFromContext(ctx); got != tt.want {
+				t.Errorf("localeFromContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDomainApiError(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(acceptLanguageKey, "en-US"))
+	err := fmt.Errorf("can't get user: %w", domain.InvalidFields("id is invalid", domain.Violation{
+		Field:       "id",
+		Description: "must be a valid UUID",
+	}))
+
+	st := status.Convert(localizeError(ctx, NewDomainApiError("get user error", err)))
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	if st.Message() != "get user error: id is invalid" {
+		t.Errorf("message = %q", st.Message())
+	}
+
+	var (
+		info       *errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+		localized  *errdetails.LocalizedMessage
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		case *errdetails.LocalizedMessage:
+			localized = d
+		}
+	}
+
+	if info == nil || info.Reason != "INVALID" || info.Domain != ErrorDomain {
+		t.Errorf("ErrorInfo = %v", info)
+	}
+	if badRequest == nil || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "id" {
+		t.Errorf("BadRequest = %v", badRequest)
+	}
+	if localized == nil || localized.Locale != LocaleEN || localized.Message != "The id is invalid." {
+		t.Errorf("LocalizedMessage = %v", localized)
+	}
+}
+
+func TestLocalizeError_status(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(acceptLanguageKey, "ru"))
+
+	st := status.Convert(localizeError(ctx, status.Error(codes.Unauthenticated, "invalid token format")))
+	if st.Code() != codes.Unauthenticated || st.Message() != "invalid token format" {
+		t.Errorf("status = %v", st)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("details = %v", st.Details())
+	}
+	localized, ok := st.Details()[0].(*errdetails.LocalizedMessage)
+	if !ok || localized.Locale != LocaleRU || localized.Message != "Необходимо авторизоваться." {
+		t.Errorf("LocalizedMessage = %v", st.Details()[0])
+	}
+}
@@ -32,10 +32,12 @@ type UserAPIClient interface {
 	GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*GetByIdResponse, error)
 	// Получение пользователя по Email.
 	GetByEmail(ctx context.Context, in *GetByEmailRequest, opts ...grpc.CallOption) (*GetByEmailResponse, error)
-	// Обновление информации о пользователе.
+	// Обновление информации о пользователе. Доступно самому пользователю и администраторам.
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
-	// Удаление пользователя.
+	// Удаление пользователя. Доступно самому пользователю и администраторам.
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Постраничное получение истории изменений пользователя, от новых записей к старым. Доступно самому пользователю и администраторам.
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 }
 
 type userAPIClient struct {
@@ -109,6 +111,15 @@ func (c *userAPIClient) Delete(ctx context.Context, in *DeleteRequest, opts ...g
 	return out, nil
 }
 
+func (c *userAPIClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/servertemplate.user.v1.UserAPI/GetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserAPIServer is the server API for UserAPI service.
 // All implementations must embed UnimplementedUserAPIServer
 // for forward compatibility
@@ -123,10 +134,12 @@ type UserAPIServer interface {
 	GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error)
 	// Получение пользователя по Email.
 	GetByEmail(context.Context, *GetByEmailRequest) (*GetByEmailResponse, error)
-	// Обновление информации о пользователе.
+	// Обновление информации о пользователе. Доступно самому пользователю и администраторам.
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
-	// Удаление пользователя.
+	// Удаление пользователя. Доступно самому пользователю и администраторам.
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Постраничное получение истории изменений пользователя, от новых записей к старым. Доступно самому пользователю и администраторам.
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	mustEmbedUnimplementedUserAPIServer()
 }
 
@@ -155,6 +168,9 @@ func (UnimplementedUserAPIServer) Update(context.Context, *UpdateRequest) (*Upda
 func (UnimplementedUserAPIServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedUserAPIServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
 func (UnimplementedUserAPIServer) mustEmbedUnimplementedUserAPIServer() {}
 
 // UnsafeUserAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -294,6 +310,24 @@ func _UserAPI_Delete_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserAPI_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAPIServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servertemplate.user.v1.UserAPI/GetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAPIServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserAPI_ServiceDesc is the grpc.ServiceDesc for UserAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -329,6 +363,10 @@ var UserAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _UserAPI_Delete_Handler,
 		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _UserAPI_GetHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "servertemplate/user/v1/user_api.proto",
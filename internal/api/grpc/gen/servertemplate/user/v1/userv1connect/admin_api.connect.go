@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: servertemplate/user/v1/admin_api.proto
+
+package userv1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// AdminAPIName is the fully-qualified name of the AdminAPI service.
+	AdminAPIName = "servertemplate.user.v1.AdminAPI"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminAPIImportUsersProcedure is the fully-qualified name of the AdminAPI's ImportUsers RPC.
+	AdminAPIImportUsersProcedure = "/servertemplate.user.v1.AdminAPI/ImportUsers"
+	// AdminAPIExportUserDataProcedure is the fully-qualified name of the AdminAPI's ExportUserData RPC.
+	AdminAPIExportUserDataProcedure = "/servertemplate.user.v1.AdminAPI/ExportUserData"
+	// AdminAPIEraseUserProcedure is the fully-qualified name of the AdminAPI's EraseUser RPC.
+	AdminAPIEraseUserProcedure = "/servertemplate.user.v1.AdminAPI/EraseUser"
+)
+
+// AdminAPIClient is a client for the servertemplate.user.v1.AdminAPI service.
+type AdminAPIClient interface {
+	// Массовый импорт пользователей из CSV или NDJSON.
+	// Первое сообщение потока должно содержать параметры импорта, остальные - части файла.
+	ImportUsers(context.Context) *connect_go.ClientStreamForClient[v1.ImportUsersRequest, v1.ImportUsersResponse]
+	// Выгрузка всех данных, хранимых о пользователе.
+	ExportUserData(context.Context, *connect_go.Request[v1.ExportUserDataRequest]) (*connect_go.Response[v1.ExportUserDataResponse], error)
+	// Удаление персональных данных пользователя с сохранением записей и связей между ними.
+	EraseUser(context.Context, *connect_go.Request[v1.EraseUserRequest]) (*connect_go.Response[v1.EraseUserResponse], error)
+}
+
+// NewAdminAPIClient constructs a client for the servertemplate.user.v1.AdminAPI service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminAPIClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AdminAPIClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &adminAPIClient{
+		importUsers: connect_go.NewClient[v1.ImportUsersRequest, v1.ImportUsersResponse](
+			httpClient,
+			baseURL+AdminAPIImportUsersProcedure,
+			opts...,
+		),
+		exportUserData: connect_go.NewClient[v1.ExportUserDataRequest, v1.ExportUserDataResponse](
+			httpClient,
+			baseURL+AdminAPIExportUserDataProcedure,
+			opts...,
+		),
+		eraseUser: connect_go.NewClient[v1.EraseUserRequest, v1.EraseUserResponse](
+			httpClient,
+			baseURL+AdminAPIEraseUserProcedure,
+			opts...,
+		),
+	}
+}
+
+// adminAPIClient implements AdminAPIClient.
+type adminAPIClient struct {
+	importUsers    *connect_go.Client[v1.ImportUsersRequest, v1.ImportUsersResponse]
+	exportUserData *connect_go.Client[v1.ExportUserDataRequest, v1.ExportUserDataResponse]
+	eraseUser      *connect_go.Client[v1.EraseUserRequest, v1.EraseUserResponse]
+}
+
+// ImportUsers calls servertemplate.user.v1.AdminAPI.ImportUsers.
+func (c *adminAPIClient) ImportUsers(ctx context.Context) *connect_go.ClientStreamForClient[v1.ImportUsersRequest, v1.ImportUsersResponse] {
+	return c.importUsers.CallClientStream(ctx)
+}
+
+// ExportUserData calls servertemplate.user.v1.AdminAPI.ExportUserData.
+func (c *adminAPIClient) ExportUserData(ctx context.Context, req *connect_go.Request[v1.ExportUserDataRequest]) (*connect_go.Response[v1.ExportUserDataResponse], error) {
+	return c.exportUserData.CallUnary(ctx, req)
+}
+
+// EraseUser calls servertemplate.user.v1.AdminAPI.EraseUser.
+func (c *adminAPIClient) EraseUser(ctx context.Context, req *connect_go.Request[v1.EraseUserRequest]) (*connect_go.Response[v1.EraseUserResponse], error) {
+	return c.eraseUser.CallUnary(ctx, req)
+}
+
+// AdminAPIHandler is an implementation of the servertemplate.user.v1.AdminAPI service.
+type AdminAPIHandler interface {
+	// Массовый импорт пользователей из CSV или NDJSON.
+	// Первое сообщение потока должно содержать параметры импорта, остальные - части файла.
+	ImportUsers(context.Context, *connect_go.ClientStream[v1.ImportUsersRequest]) (*connect_go.Response[v1.ImportUsersResponse], error)
+	// Выгрузка всех данных, хранимых о пользователе.
+	ExportUserData(context.Context, *connect_go.Request[v1.ExportUserDataRequest]) (*connect_go.Response[v1.ExportUserDataResponse], error)
+	// Удаление персональных данных пользователя с сохранением записей и связей между ними.
+	EraseUser(context.Context, *connect_go.Request[v1.EraseUserRequest]) (*connect_go.Response[v1.EraseUserResponse], error)
+}
+
+// NewAdminAPIHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminAPIHandler(svc AdminAPIHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	adminAPIImportUsersHandler := connect_go.NewClientStreamHandler(
+		AdminAPIImportUsersProcedure,
+		svc.ImportUsers,
+		opts...,
+	)
+	adminAPIExportUserDataHandler := connect_go.NewUnaryHandler(
+		AdminAPIExportUserDataProcedure,
+		svc.ExportUserData,
+		opts...,
+	)
+	adminAPIEraseUserHandler := connect_go.NewUnaryHandler(
+		AdminAPIEraseUserProcedure,
+		svc.EraseUser,
+		opts...,
+	)
+	return "/servertemplate.user.v1.AdminAPI/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminAPIImportUsersProcedure:
+			adminAPIImportUsersHandler.ServeHTTP(w, r)
+		case AdminAPIExportUserDataProcedure:
+			adminAPIExportUserDataHandler.ServeHTTP(w, r)
+		case AdminAPIEraseUserProcedure:
+			adminAPIEraseUserHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminAPIHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminAPIHandler struct{}
+
+func (UnimplementedAdminAPIHandler) ImportUsers(context.Context, *connect_go.ClientStream[v1.ImportUsersRequest]) (*connect_go.Response[v1.ImportUsersResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.AdminAPI.ImportUsers is not implemented"))
+}
+
+func (UnimplementedAdminAPIHandler) ExportUserData(context.Context, *connect_go.Request[v1.ExportUserDataRequest]) (*connect_go.Response[v1.ExportUserDataResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.AdminAPI.ExportUserData is not implemented"))
+}
+
+func (UnimplementedAdminAPIHandler) EraseUser(context.Context, *connect_go.Request[v1.EraseUserRequest]) (*connect_go.Response[v1.EraseUserResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.AdminAPI.EraseUser is not implemented"))
+}
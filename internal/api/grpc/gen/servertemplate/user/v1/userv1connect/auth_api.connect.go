@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: servertemplate/user/v1/auth_api.proto
+
+package userv1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// AuthAPIName is the fully-qualified name of the AuthAPI service.
+	AuthAPIName = "servertemplate.user.v1.AuthAPI"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AuthAPISignUpProcedure is the fully-qualified name of the AuthAPI's SignUp RPC.
+	AuthAPISignUpProcedure = "/servertemplate.user.v1.AuthAPI/SignUp"
+	// AuthAPISignInProcedure is the fully-qualified name of the AuthAPI's SignIn RPC.
+	AuthAPISignInProcedure = "/servertemplate.user.v1.AuthAPI/SignIn"
+)
+
+// AuthAPIClient is a client for the servertemplate.user.v1.AuthAPI service.
+type AuthAPIClient interface {
+	// Регистрация нового пользователя.
+	SignUp(context.Context, *connect_go.Request[v1.SignUpRequest]) (*connect_go.Response[v1.SignUpResponse], error)
+	// Вход в систему пользователя.
+	SignIn(context.Context, *connect_go.Request[v1.SignInRequest]) (*connect_go.Response[v1.SignInResponse], error)
+}
+
+// NewAuthAPIClient constructs a client for the servertemplate.user.v1.AuthAPI service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuthAPIClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AuthAPIClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &authAPIClient{
+		signUp: connect_go.NewClient[v1.SignUpRequest, v1.SignUpResponse](
+			httpClient,
+			baseURL+AuthAPISignUpProcedure,
+			opts...,
+		),
+		signIn: connect_go.NewClient[v1.SignInRequest, v1.SignInResponse](
+			httpClient,
+			baseURL+AuthAPISignInProcedure,
+			opts...,
+		),
+	}
+}
+
+// authAPIClient implements AuthAPIClient.
+type authAPIClient struct {
+	signUp *connect_go.Client[v1.SignUpRequest, v1.SignUpResponse]
+	signIn *connect_go.Client[v1.SignInRequest, v1.SignInResponse]
+}
+
+// SignUp calls servertemplate.user.v1.AuthAPI.SignUp.
+func (c *authAPIClient) SignUp(ctx context.Context, req *connect_go.Request[v1.SignUpRequest]) (*connect_go.Response[v1.SignUpResponse], error) {
+	return c.signUp.CallUnary(ctx, req)
+}
+
+// SignIn calls servertemplate.user.v1.AuthAPI.SignIn.
+func (c *authAPIClient) SignIn(ctx context.Context, req *connect_go.Request[v1.SignInRequest]) (*connect_go.Response[v1.SignInResponse], error) {
+	return c.signIn.CallUnary(ctx, req)
+}
+
+// AuthAPIHandler is an implementation of the servertemplate.user.v1.AuthAPI service.
+type AuthAPIHandler interface {
+	// Регистрация нового пользователя.
+	SignUp(context.Context, *connect_go.Request[v1.SignUpRequest]) (*connect_go.Response[v1.SignUpResponse], error)
+	// Вход в систему пользователя.
+	SignIn(context.Context, *connect_go.Request[v1.SignInRequest]) (*connect_go.Response[v1.SignInResponse], error)
+}
+
+// NewAuthAPIHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuthAPIHandler(svc AuthAPIHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	authAPISignUpHandler := connect_go.NewUnaryHandler(
+		AuthAPISignUpProcedure,
+		svc.SignUp,
+		opts...,
+	)
+	authAPISignInHandler := connect_go.NewUnaryHandler(
+		AuthAPISignInProcedure,
+		svc.SignIn,
+		opts...,
+	)
+	return "/servertemplate.user.v1.AuthAPI/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuthAPISignUpProcedure:
+			authAPISignUpHandler.ServeHTTP(w, r)
+		case AuthAPISignInProcedure:
+			authAPISignInHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuthAPIHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuthAPIHandler struct{}
+
+func (UnimplementedAuthAPIHandler) SignUp(context.Context, *connect_go.Request[v1.SignUpRequest]) (*connect_go.Response[v1.SignUpResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.AuthAPI.SignUp is not implemented"))
+}
+
+func (UnimplementedAuthAPIHandler) SignIn(context.Context, *connect_go.Request[v1.SignInRequest]) (*connect_go.Response[v1.SignInResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.AuthAPI.SignIn is not implemented"))
+}
@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: servertemplate/user/v1/user_api.proto
+
+package userv1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// UserAPIName is the fully-qualified name of the UserAPI service.
+	UserAPIName = "servertemplate.user.v1.UserAPI"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// UserAPIGetMeProcedure is the fully-qualified name of the UserAPI's GetMe RPC.
+	UserAPIGetMeProcedure = "/servertemplate.user.v1.UserAPI/GetMe"
+	// UserAPIUpdateMeProcedure is the fully-qualified name of the UserAPI's UpdateMe RPC.
+	UserAPIUpdateMeProcedure = "/servertemplate.user.v1.UserAPI/UpdateMe"
+	// UserAPIDeleteMeProcedure is the fully-qualified name of the UserAPI's DeleteMe RPC.
+	UserAPIDeleteMeProcedure = "/servertemplate.user.v1.UserAPI/DeleteMe"
+	// UserAPIGetByIdProcedure is the fully-qualified name of the UserAPI's GetById RPC.
+	UserAPIGetByIdProcedure = "/servertemplate.user.v1.UserAPI/GetById"
+	// UserAPIGetByEmailProcedure is the fully-qualified name of the UserAPI's GetByEmail RPC.
+	UserAPIGetByEmailProcedure = "/servertemplate.user.v1.UserAPI/GetByEmail"
+	// UserAPIUpdateProcedure is the fully-qualified name of the UserAPI's Update RPC.
+	UserAPIUpdateProcedure = "/servertemplate.user.v1.UserAPI/Update"
+	// UserAPIDeleteProcedure is the fully-qualified name of the UserAPI's Delete RPC.
+	UserAPIDeleteProcedure = "/servertemplate.user.v1.UserAPI/Delete"
+	// UserAPIGetHistoryProcedure is the fully-qualified name of the UserAPI's GetHistory RPC.
+	UserAPIGetHistoryProcedure = "/servertemplate.user.v1.UserAPI/GetHistory"
+)
+
+// UserAPIClient is a client for the servertemplate.user.v1.UserAPI service.
+type UserAPIClient interface {
+	// Получение информации о пользователе по ID из JWT токена.
+	GetMe(context.Context, *connect_go.Request[v1.GetMeRequest]) (*connect_go.Response[v1.GetMeResponse], error)
+	// Обновление информации о пользователе из JWT токена.
+	UpdateMe(context.Context, *connect_go.Request[v1.UpdateMeRequest]) (*connect_go.Response[v1.UpdateMeResponse], error)
+	// Удаление информации о пользователе из JWT токена.
+	DeleteMe(context.Context, *connect_go.Request[v1.DeleteMeRequest]) (*connect_go.Response[v1.DeleteMeResponse], error)
+	// Получение пользователя по ID.
+	GetById(context.Context, *connect_go.Request[v1.GetByIdRequest]) (*connect_go.Response[v1.GetByIdResponse], error)
+	// Получение пользователя по Email.
+	GetByEmail(context.Context, *connect_go.Request[v1.GetByEmailRequest]) (*connect_go.Response[v1.GetByEmailResponse], error)
+	// Обновление информации о пользователе. Доступно самому пользователю и администраторам.
+	Update(context.Context, *connect_go.Request[v1.UpdateRequest]) (*connect_go.Response[v1.UpdateResponse], error)
+	// Удаление пользователя. Доступно самому пользователю и администраторам.
+	Delete(context.Context, *connect_go.Request[v1.DeleteRequest]) (*connect_go.Response[v1.DeleteResponse], error)
+	// Постраничное получение истории изменений пользователя, от новых записей к старым. Доступно самому пользователю и администраторам.
+	GetHistory(context.Context, *connect_go.Request[v1.GetHistoryRequest]) (*connect_go.Response[v1.GetHistoryResponse], error)
+}
+
+// NewUserAPIClient constructs a client for the servertemplate.user.v1.UserAPI service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewUserAPIClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) UserAPIClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &userAPIClient{
+		getMe: connect_go.NewClient[v1.GetMeRequest, v1.GetMeResponse](
+			httpClient,
+			baseURL+UserAPIGetMeProcedure,
+			opts...,
+		),
+		updateMe: connect_go.NewClient[v1.UpdateMeRequest, v1.UpdateMeResponse](
+			httpClient,
+			baseURL+UserAPIUpdateMeProcedure,
+			opts...,
+		),
+		deleteMe: connect_go.NewClient[v1.DeleteMeRequest, v1.DeleteMeResponse](
+			httpClient,
+			baseURL+UserAPIDeleteMeProcedure,
+			opts...,
+		),
+		getById: connect_go.NewClient[v1.GetByIdRequest, v1.GetByIdResponse](
+			httpClient,
+			baseURL+UserAPIGetByIdProcedure,
+			opts...,
+		),
+		getByEmail: connect_go.NewClient[v1.GetByEmailRequest, v1.GetByEmailResponse](
+			httpClient,
+			baseURL+UserAPIGetByEmailProcedure,
+			opts...,
+		),
+		update: connect_go.NewClient[v1.UpdateRequest, v1.UpdateResponse](
+			httpClient,
+			baseURL+UserAPIUpdateProcedure,
+			opts...,
+		),
+		delete: connect_go.NewClient[v1.DeleteRequest, v1.DeleteResponse](
+			httpClient,
+			baseURL+UserAPIDeleteProcedure,
+			opts...,
+		),
+		getHistory: connect_go.NewClient[v1.GetHistoryRequest, v1.GetHistoryResponse](
+			httpClient,
+			baseURL+UserAPIGetHistoryProcedure,
+			opts...,
+		),
+	}
+}
+
+// userAPIClient implements UserAPIClient.
+type userAPIClient struct {
+	getMe      *connect_go.Client[v1.GetMeRequest, v1.GetMeResponse]
+	updateMe   *connect_go.Client[v1.UpdateMeRequest, v1.UpdateMeResponse]
+	deleteMe   *connect_go.Client[v1.DeleteMeRequest, v1.DeleteMeResponse]
+	getById    *connect_go.Client[v1.GetByIdRequest, v1.GetByIdResponse]
+	getByEmail *connect_go.Client[v1.GetByEmailRequest, v1.GetByEmailResponse]
+	update     *connect_go.Client[v1.UpdateRequest, v1.UpdateResponse]
+	delete     *connect_go.Client[v1.DeleteRequest, v1.DeleteResponse]
+	getHistory *connect_go.Client[v1.GetHistoryRequest, v1.GetHistoryResponse]
+}
+
+// GetMe calls servertemplate.user.v1.UserAPI.GetMe.
+func (c *userAPIClient) GetMe(ctx context.Context, req *connect_go.Request[v1.GetMeRequest]) (*connect_go.Response[v1.GetMeResponse], error) {
+	return c.getMe.CallUnary(ctx, req)
+}
+
+// UpdateMe calls servertemplate.user.v1.UserAPI.UpdateMe.
+func (c *userAPIClient) UpdateMe(ctx context.Context, req *connect_go.Request[v1.UpdateMeRequest]) (*connect_go.Response[v1.UpdateMeResponse], error) {
+	return c.updateMe.CallUnary(ctx, req)
+}
+
+// DeleteMe calls servertemplate.user.v1.UserAPI.DeleteMe.
+func (c *userAPIClient) DeleteMe(ctx context.Context, req *connect_go.Request[v1.DeleteMeRequest]) (*connect_go.Response[v1.DeleteMeResponse], error) {
+	return c.deleteMe.CallUnary(ctx, req)
+}
+
+// GetById calls servertemplate.user.v1.UserAPI.GetById.
+func (c *userAPIClient) GetById(ctx context.Context, req *connect_go.Request[v1.GetByIdRequest]) (*connect_go.Response[v1.GetByIdResponse], error) {
+	return c.getById.CallUnary(ctx, req)
+}
+
+// GetByEmail calls servertemplate.user.v1.UserAPI.GetByEmail.
+func (c *userAPIClient) GetByEmail(ctx context.Context, req *connect_go.Request[v1.GetByEmailRequest]) (*connect_go.Response[v1.GetByEmailResponse], error) {
+	return c.getByEmail.CallUnary(ctx, req)
+}
+
+// Update calls servertemplate.user.v1.UserAPI.Update.
+func (c *userAPIClient) Update(ctx context.Context, req *connect_go.Request[v1.UpdateRequest]) (*connect_go.Response[v1.UpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls servertemplate.user.v1.UserAPI.Delete.
+func (c *userAPIClient) Delete(ctx context.Context, req *connect_go.Request[v1.DeleteRequest]) (*connect_go.Response[v1.DeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// GetHistory calls servertemplate.user.v1.UserAPI.GetHistory.
+func (c *userAPIClient) GetHistory(ctx context.Context, req *connect_go.Request[v1.GetHistoryRequest]) (*connect_go.Response[v1.GetHistoryResponse], error) {
+	return c.getHistory.CallUnary(ctx, req)
+}
+
+// UserAPIHandler is an implementation of the servertemplate.user.v1.UserAPI service.
+type UserAPIHandler interface {
+	// Получение информации о пользователе по ID из JWT токена.
+	GetMe(context.Context, *connect_go.Request[v1.GetMeRequest]) (*connect_go.Response[v1.GetMeResponse], error)
+	// Обновление информации о пользователе из JWT токена.
+	UpdateMe(context.Context, *connect_go.Request[v1.UpdateMeRequest]) (*connect_go.Response[v1.UpdateMeResponse], error)
+	// Удаление информации о пользователе из JWT токена.
+	DeleteMe(context.Context, *connect_go.Request[v1.DeleteMeRequest]) (*connect_go.Response[v1.DeleteMeResponse], error)
+	// Получение пользователя по ID.
+	GetById(context.Context, *connect_go.Request[v1.GetByIdRequest]) (*connect_go.Response[v1.GetByIdResponse], error)
+	// Получение пользователя по Email.
+	GetByEmail(context.Context, *connect_go.Request[v1.GetByEmailRequest]) (*connect_go.Response[v1.GetByEmailResponse], error)
+	// Обновление информации о пользователе. Доступно самому пользователю и администраторам.
+	Update(context.Context, *connect_go.Request[v1.UpdateRequest]) (*connect_go.Response[v1.UpdateResponse], error)
+	// Удаление пользователя. Доступно самому пользователю и администраторам.
+	Delete(context.Context, *connect_go.Request[v1.DeleteRequest]) (*connect_go.Response[v1.DeleteResponse], error)
+	// Постраничное получение истории изменений пользователя, от новых записей к старым. Доступно самому пользователю и администраторам.
+	GetHistory(context.Context, *connect_go.Request[v1.GetHistoryRequest]) (*connect_go.Response[v1.GetHistoryResponse], error)
+}
+
+// NewUserAPIHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewUserAPIHandler(svc UserAPIHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	userAPIGetMeHandler := connect_go.NewUnaryHandler(
+		UserAPIGetMeProcedure,
+		svc.GetMe,
+		opts...,
+	)
+	userAPIUpdateMeHandler := connect_go.NewUnaryHandler(
+		UserAPIUpdateMeProcedure,
+		svc.UpdateMe,
+		opts...,
+	)
+	userAPIDeleteMeHandler := connect_go.NewUnaryHandler(
+		UserAPIDeleteMeProcedure,
+		svc.DeleteMe,
+		opts...,
+	)
+	userAPIGetByIdHandler := connect_go.NewUnaryHandler(
+		UserAPIGetByIdProcedure,
+		svc.GetById,
+		opts...,
+	)
+	userAPIGetByEmailHandler := connect_go.NewUnaryHandler(
+		UserAPIGetByEmailProcedure,
+		svc.GetByEmail,
+		opts...,
+	)
+	userAPIUpdateHandler := connect_go.NewUnaryHandler(
+		UserAPIUpdateProcedure,
+		svc.Update,
+		opts...,
+	)
+	userAPIDeleteHandler := connect_go.NewUnaryHandler(
+		UserAPIDeleteProcedure,
+		svc.Delete,
+		opts...,
+	)
+	userAPIGetHistoryHandler := connect_go.NewUnaryHandler(
+		UserAPIGetHistoryProcedure,
+		svc.GetHistory,
+		opts...,
+	)
+	return "/servertemplate.user.v1.UserAPI/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case UserAPIGetMeProcedure:
+			userAPIGetMeHandler.ServeHTTP(w, r)
+		case UserAPIUpdateMeProcedure:
+			userAPIUpdateMeHandler.ServeHTTP(w, r)
+		case UserAPIDeleteMeProcedure:
+			userAPIDeleteMeHandler.ServeHTTP(w, r)
+		case UserAPIGetByIdProcedure:
+			userAPIGetByIdHandler.ServeHTTP(w, r)
+		case UserAPIGetByEmailProcedure:
+			userAPIGetByEmailHandler.ServeHTTP(w, r)
+		case UserAPIUpdateProcedure:
+			userAPIUpdateHandler.ServeHTTP(w, r)
+		case UserAPIDeleteProcedure:
+			userAPIDeleteHandler.ServeHTTP(w, r)
+		case UserAPIGetHistoryProcedure:
+			userAPIGetHistoryHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedUserAPIHandler returns CodeUnimplemented from all methods.
+type UnimplementedUserAPIHandler struct{}
+
+func (UnimplementedUserAPIHandler) GetMe(context.Context, *connect_go.Request[v1.GetMeRequest]) (*connect_go.Response[v1.GetMeResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.GetMe is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) UpdateMe(context.Context, *connect_go.Request[v1.UpdateMeRequest]) (*connect_go.Response[v1.UpdateMeResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.UpdateMe is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) DeleteMe(context.Context, *connect_go.Request[v1.DeleteMeRequest]) (*connect_go.Response[v1.DeleteMeResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.DeleteMe is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) GetById(context.Context, *connect_go.Request[v1.GetByIdRequest]) (*connect_go.Response[v1.GetByIdResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.GetById is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) GetByEmail(context.Context, *connect_go.Request[v1.GetByEmailRequest]) (*connect_go.Response[v1.GetByEmailResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.GetByEmail is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) Update(context.Context, *connect_go.Request[v1.UpdateRequest]) (*connect_go.Response[v1.UpdateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.Update is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) Delete(context.Context, *connect_go.Request[v1.DeleteRequest]) (*connect_go.Response[v1.DeleteResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.Delete is not implemented"))
+}
+
+func (UnimplementedUserAPIHandler) GetHistory(context.Context, *connect_go.Request[v1.GetHistoryRequest]) (*connect_go.Response[v1.GetHistoryResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("servertemplate.user.v1.UserAPI.GetHistory is not implemented"))
+}
@@ -2,8 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 type interceptor struct {
@@ -13,6 +16,7 @@ func NewInterceptor() *interceptor {
 	return &interceptor{}
 }
 
+// Unary добавляет к ошибкам ответа LocalizedMessage на языке вызывающего
 func (i *interceptor) Unary() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -20,10 +24,12 @@ func (i *interceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (resp interface{}, err error) {
-		return handler(ctx, req)
+		resp, err = handler(ctx, req)
+		return resp, localizeError(ctx, err)
 	}
 }
 
+// Stream добавляет к ошибкам ответа LocalizedMessage на языке вызывающего
 func (i *interceptor) Stream() grpc.StreamServerInterceptor {
 	return func(
 		srv interface{},
@@ -31,6 +37,45 @@ func (i *interceptor) Stream() grpc.StreamServerInterceptor {
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		return handler(srv, ss)
+		return localizeError(ss.Context(), handler(srv, ss))
 	}
 }
+
+// localizeError добавляет к ошибке сообщение из каталога, если оно не было задано обработчиком.
+//
+//	apiError дополняется на месте, чтобы в лог попала исходная причина ошибки.
+func localizeError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	locale := localeFromContext(ctx)
+
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		if apiErr.localizeMessage == nil {
+			apiErr.WithLocalizedMessage(locale, localizedMessage(locale, apiErr.messageKey, apiErr.Code))
+		}
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		if _, ok := detail.(*errdetails.LocalizedMessage); ok {
+			return err
+		}
+	}
+
+	localized, detailsErr := st.WithDetails(&errdetails.LocalizedMessage{
+		Locale:  locale,
+		Message: localizedMessage(locale, "", st.Code()),
+	})
+	if detailsErr != nil {
+		return err
+	}
+
+	return localized.Err()
+}
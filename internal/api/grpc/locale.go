@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Поддерживаемые языки сообщений об ошибках
+const (
+	LocaleRU = "ru-RU"
+	LocaleEN = "en-US"
+)
+
+// acceptLanguageKey ключ метаданных с предпочитаемыми языками клиента (формат HTTP Accept-Language)
+const acceptLanguageKey = "accept-language"
+
+// Первый язык используется по умолчанию
+var (
+	supportedLocales = []string{LocaleRU, LocaleEN}
+	localeMatcher    = language.NewMatcher([]language.Tag{
+		language.MustParse(LocaleRU),
+		language.MustParse(LocaleEN),
+	})
+)
+
+type localeMessages struct {
+	codes    map[codes.Code]string // Сообщения по коду ответа
+	messages map[string]string     // Сообщения по тексту ошибки предметной области
+}
+
+// messageCatalog каталог сообщений об ошибках для LocalizedMessage
+var messageCatalog = map[string]localeMessages{
+	LocaleRU: {
+		codes: map[codes.Code]string{
+			codes.Internal:           "Что-то пошло не так. Попробуйте еще раз.",
+			codes.Unknown:            "Что-то пошло не так. Попробуйте еще раз.",
+			codes.NotFound:           "Объект не найден.",
+			codes.AlreadyExists:      "Объект уже существует.",
+			codes.InvalidArgument:    "Некорректные данные запроса.",
+			codes.PermissionDenied:   "Недостаточно прав для выполнения операции.",
+			codes.Unauthenticated:    "Необходимо авторизоваться.",
+			codes.Aborted:            "Запрос прерван. Попробуйте еще раз.",
+			codes.Canceled:           "Запрос отменен.",
+			codes.DeadlineExceeded:   "Превышено время ожидания ответа.",
+			codes.Unavailable:        "Сервис временно недоступен.",
+			codes.Unimplemented:      "Метод не поддерживается.",
+			codes.ResourceExhausted:  "Превышен лимит запросов.",
+			codes.FailedPrecondition: "Операция невозможна в текущем состоянии.",
+		},
+		messages: map[string]string{
+			"user not found":         "Пользователь не найден.",
+			"email is already taken": "Электронная почта уже занята.",
+			"malformed import data":  "Некорректные данные импорта.",
+			"invalid export options": "Некорректные параметры выгрузки.",
+			"export job not found":   "Задача выгрузки не найдена.",
+			"id is invalid":          "Некорректный идентификатор.",
+			"options are required":   "Не указаны параметры импорта.",
+			"admin role is required": "Операция доступна только администратору.",
+		},
+	},
+	LocaleEN: {
+		codes: map[codes.Code]string{
+			codes.Internal:           "Something went wrong. Please try again.",
+			codes.Unknown:            "Something went wrong. Please try again.",
+			codes.NotFound:           "The requested object was not found.",
+			codes.AlreadyExists:      "The object already exists.",
+			codes.InvalidArgument:    "The request contains invalid data.",
+			codes.PermissionDenied:   "You don't have permission to perform this operation.",
+			codes.Unauthenticated:    "Authentication is required.",
+			codes.Aborted:            "The request was aborted. Please try again.",
+			codes.Canceled:           "The request was canceled.",
+			codes.DeadlineExceeded:   "The request timed out.",
+			codes.Unavailable:        "The service is temporarily unavailable.",
+			codes.Unimplemented:      "The method is not supported.",
+			codes.ResourceExhausted:  "Too many requests.",
+			codes.FailedPrecondition: "The operation is not allowed in the current state.",
+		},
+		messages: map[string]string{
+			"user not found":         "User not found.",
+			"email is already taken": "The email is already taken.",
+			"malformed import data":  "The import data is malformed.",
+			"invalid export options": "The export options are invalid.",
+			"export job not found":   "Export job not found.",
+			"id is invalid":          "The id is invalid.",
+			"options are required":   "Import options are required.",
+			"admin role is required": "The operation requires the admin role.",
+		},
+	},
+}
+
+// localeFromContext выбирает язык сообщений по метаданным accept-language вызывающего
+func localeFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return supportedLocales[0]
+	}
+
+	var tags []language.Tag
+	for _, value := range md.Get(acceptLanguageKey) {
+		parsed, _, err := language.ParseAcceptLanguage(value)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, parsed...)
+	}
+	if len(tags) == 0 {
+		return supportedLocales[0]
+	}
+
+	_, index, _ := localeMatcher.Match(tags...)
+	return supportedLocales[index]
+}
+
+// localizedMessage возвращает сообщение из каталога: сначала по тексту ошибки предметной области, затем по коду
+func localizedMessage(locale, key string, code codes.Code) string {
+	catalog, ok := messageCatalog[locale]
+	if !ok {
+		catalog = messageCatalog[supportedLocales[0]]
+	}
+
+	if msg, ok := catalog.messages[key]; ok {
+		return msg
+	}
+	if msg, ok := catalog.codes[code]; ok {
+		return msg
+	}
+
+	return catalog.codes[codes.Internal]
+}
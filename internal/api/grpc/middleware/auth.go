@@ -2,9 +2,13 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"go-test-grpc-http/internal/domain"
 	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
 	"strings"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -15,30 +19,109 @@ type contextKey = string
 
 const userIDKey contextKey = "user-id"
 
-func NewAuthMiddleware() grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Aborted, "metadata not found")
-		}
+// Методы, доступные без авторизации, токен в них не проверяется (полные имена методов)
+var publicMethods = map[string]bool{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      true,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+	"/grpc.health.v1.Health/Check":                                   true,
+	"/grpc.health.v1.Health/Watch":                                   true,
+	"/servertemplate.user.v1.AuthAPI/SignUp":                         true,
+	"/servertemplate.user.v1.AuthAPI/SignIn":                         true,
+}
 
-		authorization := md.Get("authorization")
-		if len(authorization) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "can't find authorization")
-		}
+// Методы только для чтения, доступные без токена. Переданный токен проверяется, чтобы обработчик
+// знал вызывающего. Остальные методы, в том числе новые, требуют токен.
+var anonymousMethods = map[string]bool{
+	"/servertemplate.user.v1.UserAPI/GetById": true,
+}
 
-		tokenString := strings.TrimPrefix(authorization[0], "Bearer ")
-		if len(tokenString) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token format")
+// NewAuthMiddleware проверяет токен вызывающего. Токены удаленных и заблокированных пользователей отклоняются.
+func NewAuthMiddleware(interactor usecase.UserInteractor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		c, err := authorize(ctx, interactor, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
 
-		id, err := entity.ParseToken(tokenString)
+		return handler(c, req)
+	}
+}
+
+func NewStreamAuthMiddleware(interactor usecase.UserInteractor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c, err := authorize(ss.Context(), interactor, info.FullMethod)
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+			return err
 		}
 
-		c := context.WithValue(ctx, userIDKey, id.Id)
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = c
 
-		return handler(c, req)
+		return handler(srv, wrapped)
+	}
+}
+
+// UserIDFromContext возвращает ID пользователя, прошедшего авторизацию
+func UserIDFromContext(ctx context.Context) (*entity.UserID, bool) {
+	id, ok := ctx.Value(userIDKey).(*entity.UserID)
+	return id, ok
+}
+
+// ContextWithUserID возвращает контекст с ID пользователя, прошедшего авторизацию
+func ContextWithUserID(ctx context.Context, id *entity.UserID) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+// authorize требует токен для всех методов, кроме публичных и доступных анонимно.
+// Анонимный запрос без токена пропускается без вызывающего.
+func authorize(ctx context.Context, interactor usecase.UserInteractor, fullMethod string) (context.Context, error) {
+	if publicMethods[fullMethod] {
+		return ctx, nil
 	}
+	if anonymousMethods[fullMethod] && !hasAuthorization(ctx) {
+		return ctx, nil
+	}
+
+	return authenticate(ctx, interactor)
+}
+
+func authenticate(ctx context.Context, interactor usecase.UserInteractor) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Aborted, "metadata not found")
+	}
+
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "can't find authorization")
+	}
+
+	tokenString := strings.TrimPrefix(authorization[0], "Bearer ")
+	if len(tokenString) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token format")
+	}
+
+	id, err := entity.ParseToken(tokenString)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	// Токен действует до истечения срока, поэтому блокировка проверяется при каждом запросе
+	user, err := interactor.GetById(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, status.Errorf(codes.Unauthenticated, "user is not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't get user: %v", err)
+	}
+	if user.DisabledAt != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", entity.ErrUserDisabled)
+	}
+
+	return entity.ContextWithActor(ContextWithUserID(ctx, id), id), nil
+}
+
+func hasAuthorization(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
 }
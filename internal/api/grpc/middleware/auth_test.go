@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewAuthMiddleware(t *testing.T) {
+	config.SetAppConfig(&config.Config{ApiKey: "secret"})
+
+	id := &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}
+	disabledID := &entity.UserID{Id: uuid.MustParse("a1f0c5e4-3f5b-4d8e-9a7c-2b6d8e4f1c3a")}
+	deletedID := &entity.UserID{Id: uuid.MustParse("0b8e7c2d-5a4f-4e3b-8c1d-9f6a2e5b7d40")}
+	token := generateToken(t, id)
+	disabledAt := time.Now()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	interactor := usecase.NewMockUserInteractor(ctrl)
+	interactor.EXPECT().GetById(gomock.Any(), id).Return(&entity.User{ID: id}, nil).AnyTimes()
+	interactor.EXPECT().GetById(gomock.Any(), disabledID).Return(&entity.User{ID: disabledID, DisabledAt: &disabledAt}, nil).AnyTimes()
+	interactor.EXPECT().GetById(gomock.Any(), deletedID).Return(nil, domain.ErrNotFound).AnyTimes()
+
+	const (
+		getByIdMethod = "/servertemplate.user.v1.UserAPI/GetById"
+		updateMethod  = "/servertemplate.user.v1.UserAPI/Update"
+		adminMethod   = "/servertemplate.user.v1.AdminAPI/ExportUserData"
+		authMethod    = "/servertemplate.user.v1.AuthAPI/SignIn"
+		healthMethod  = "/grpc.health.v1.Health/Check"
+		unknownMethod = "/servertemplate.user.v1.UserAPI/NewMethod"
+	)
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		wantCode      codes.Code
+		wantUser      bool
+	}{
+		{name: "anonymous method without token", method: getByIdMethod, wantCode: codes.OK},
+		{name: "anonymous method with token", method: getByIdMethod, authorization: "Bearer " + token, wantCode: codes.OK, wantUser: true},
+		{name: "anonymous method with invalid token", method: getByIdMethod, authorization: "Bearer invalid", wantCode: codes.Unauthenticated},
+		{name: "user api update without token", method: updateMethod, wantCode: codes.Unauthenticated},
+		{name: "user api update with token", method: updateMethod, authorization: "Bearer " + token, wantCode: codes.OK, wantUser: true},
+		{name: "unlisted method without token", method: unknownMethod, wantCode: codes.Unauthenticated},
+		{name: "admin api without token", method: adminMethod, wantCode: codes.Unauthenticated},
+		{name: "admin api with token", method: adminMethod, authorization: "Bearer " + token, wantCode: codes.OK, wantUser: true},
+		{name: "public method ignores token", method: authMethod, authorization: "Bearer invalid", wantCode: codes.OK},
+		{name: "health without token", method: healthMethod, wantCode: codes.OK},
+		{name: "disabled user", method: adminMethod, authorization: "Bearer " + generateToken(t, disabledID), wantCode: codes.PermissionDenied},
+		{name: "disabled user on anonymous method", method: getByIdMethod, authorization: "Bearer " + generateToken(t, disabledID), wantCode: codes.PermissionDenied},
+		{name: "deleted user", method: updateMethod, authorization: "Bearer " + generateToken(t, deletedID), wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.authorization != "" {
+				md.Set("authorization", tt.authorization)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+
+			var gotUser *entity.UserID
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotUser, _ = UserIDFromContext(ctx)
+				return nil, nil
+			}
+			_, err := NewAuthMiddleware(interactor)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("NewAuthMiddleware() error = %v, want code %v", err, tt.wantCode)
+			}
+			if tt.wantUser && (gotUser == nil || gotUser.Id != id.Id) {
+				t.Errorf("UserIDFromContext() = %v, want %v", gotUser, id)
+			}
+			if !tt.wantUser && gotUser != nil {
+				t.Errorf("UserIDFromContext() = %v, want none", gotUser)
+			}
+		})
+	}
+}
+
+func generateToken(t *testing.T, id *entity.UserID) string {
+	t.Helper()
+	token, err := entity.GenerateToken(id).String()
+	if err != nil {
+		t.Fatalf("can't generate token: %v", err)
+	}
+	return token
+}
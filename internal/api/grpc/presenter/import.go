@@ -0,0 +1,76 @@
+package presenter
+
+import (
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/entity"
+)
+
+type importPresenter struct {
+}
+
+func NewImportPresenter() *importPresenter {
+	return &importPresenter{}
+}
+
+func (i *importPresenter) ToUserImportOptions(opts *userv1.ImportUsersOptions) *entity.UserImportOptions {
+	res := &entity.UserImportOptions{
+		DryRun:    opts.GetDryRun(),
+		BatchSize: int(opts.GetBatchSize()),
+	}
+
+	switch opts.GetFormat() {
+	case userv1.ImportFormat_IMPORT_FORMAT_CSV:
+		res.Format = entity.DataFormatCSV
+	case userv1.ImportFormat_IMPORT_FORMAT_NDJSON:
+		res.Format = entity.DataFormatNDJSON
+	}
+
+	switch opts.GetMode() {
+	case userv1.ImportMode_IMPORT_MODE_ALL_OR_NOTHING:
+		res.Mode = entity.ImportModeAllOrNothing
+	case userv1.ImportMode_IMPORT_MODE_BEST_EFFORT:
+		res.Mode = entity.ImportModeBestEffort
+	}
+
+	return res
+}
+
+func (i *importPresenter) FromUserImportReport(report *entity.UserImportReport) *userv1.ImportUsersResponse {
+	results := make([]*userv1.ImportUserResult, 0, len(report.Results))
+	for _, result := range report.Results {
+		res := &userv1.ImportUserResult{
+			Line:   int32(result.Line),
+			Email:  result.Email,
+			Status: fromImportStatus(result.Status),
+			Error:  result.Error,
+		}
+		if result.ID != nil {
+			res.Id = result.ID.String()
+		}
+		results = append(results, res)
+	}
+
+	return &userv1.ImportUsersResponse{
+		DryRun:    report.DryRun,
+		Committed: report.Committed,
+		Total:     int32(report.Total),
+		Created:   int32(report.Created),
+		Failed:    int32(report.Failed),
+		Results:   results,
+	}
+}
+
+func fromImportStatus(status entity.ImportStatus) userv1.ImportStatus {
+	switch status {
+	case entity.ImportStatusCreated:
+		return userv1.ImportStatus_IMPORT_STATUS_CREATED
+	case entity.ImportStatusValid:
+		return userv1.ImportStatus_IMPORT_STATUS_VALID
+	case entity.ImportStatusFailed:
+		return userv1.ImportStatus_IMPORT_STATUS_FAILED
+	case entity.ImportStatusRolledBack:
+		return userv1.ImportStatus_IMPORT_STATUS_ROLLED_BACK
+	default:
+		return userv1.ImportStatus_IMPORT_STATUS_INVALID
+	}
+}
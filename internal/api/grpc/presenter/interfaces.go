@@ -7,10 +7,20 @@ import (
 
 type UserPresenter interface {
 	ToUser(user *userv1.UserDB) *entity.User
-	FromUser(user *entity.User) *userv1.UserDB
+	FromUser(user *entity.User, audience entity.UserAudience) *userv1.User
 
 	ToUserID(id string) *entity.UserID
 
 	ToUserCreate(user *userv1.UserCreate) *entity.UserCreate
 	FromUserCreate(user *entity.UserCreate) *userv1.UserCreate
+	ToUserUpdate(user *userv1.UserUpdate) *entity.UserCreate
+
+	FromUserHistoryPage(page *entity.UserHistoryPage, audience entity.UserAudience) *userv1.GetHistoryResponse
+	ToHistoryCursor(token string) (int64, error)
+	FromUserDataArchive(archive *entity.UserDataArchive) *userv1.ExportUserDataResponse
+}
+
+type ImportPresenter interface {
+	ToUserImportOptions(opts *userv1.ImportUsersOptions) *entity.UserImportOptions
+	FromUserImportReport(report *entity.UserImportReport) *userv1.ImportUsersResponse
 }
@@ -1,10 +1,13 @@
 package presenter
 
 import (
+	"fmt"
 	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
 	"go-test-grpc-http/internal/entity"
+	"strconv"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type userPresenter struct {
@@ -14,19 +17,33 @@ func NewUserPresenter() *userPresenter {
 	return &userPresenter{}
 }
 
-// TODO почекать выходные данные, чтобы не отправлять лишнюю инфу о юзерах
-
-func (u *userPresenter) FromUser(user *entity.User) *userv1.UserDB {
-	return &userv1.UserDB{
+// FromUser возвращает поля пользователя, доступные аудитории. Пароль не передается.
+func (u *userPresenter) FromUser(user *entity.User, audience entity.UserAudience) *userv1.User {
+	res := &userv1.User{
 		Id:         user.ID.String(),
 		FirstName:  user.FirstName,
 		SecondName: user.SecondName,
 		LastName:   user.LastName,
-		Age:        int32(user.Age),
-		Password:   user.Password,
-		Email:      user.Email,
-		Phone:      user.Phone,
+		CreatedAt:  timestamppb.New(user.CreatedAt),
+	}
+	if audience == entity.UserAudienceOther {
+		return res
+	}
+
+	res.Age = int32(user.Age)
+	res.Email = user.Email
+	res.Phone = user.Phone
+	res.UpdatedAt = timestamppb.New(user.UpdatedAt)
+	if audience != entity.UserAudienceAdmin {
+		return res
+	}
+
+	res.Role = string(user.Role)
+	if user.ErasedAt != nil {
+		res.ErasedAt = timestamppb.New(*user.ErasedAt)
 	}
+
+	return res
 }
 
 func (u *userPresenter) ToUser(user *userv1.UserDB) *entity.User {
@@ -39,6 +56,8 @@ func (u *userPresenter) ToUser(user *userv1.UserDB) *entity.User {
 		Password:   user.GetPassword(),
 		Email:      user.GetEmail(),
 		Phone:      user.GetPhone(),
+		CreatedAt:  user.GetCreatedAt().AsTime(),
+		UpdatedAt:  user.GetUpdatedAt().AsTime(),
 	}
 }
 
@@ -75,3 +94,130 @@ func (u *userPresenter) FromUserCreate(user *entity.UserCreate) *userv1.UserCrea
 		Phone:      user.Phone,
 	}
 }
+
+func (u *userPresenter) ToUserUpdate(user *userv1.UserUpdate) *entity.UserCreate {
+	return &entity.UserCreate{
+		FirstName:  user.GetFirstName(),
+		SecondName: user.GetSecondName(),
+		LastName:   user.GetLastName(),
+		Age:        int(user.GetAge()),
+		Password:   user.GetPassword(),
+		Email:      user.GetEmail(),
+		Phone:      user.GetPhone(),
+	}
+}
+
+// FromUserHistoryPage возвращает страницу истории, снимки в которой содержат поля, доступные аудитории
+func (u *userPresenter) FromUserHistoryPage(page *entity.UserHistoryPage, audience entity.UserAudience) *userv1.GetHistoryResponse {
+	res := &userv1.GetHistoryResponse{}
+	if page.NextCursor > 0 {
+		res.NextPageToken = strconv.FormatInt(page.NextCursor, 10)
+	}
+	res.Entries = fromUserHistoryEntries(page.Entries, audience)
+
+	return res
+}
+
+func (u *userPresenter) FromUserDataArchive(archive *entity.UserDataArchive) *userv1.ExportUserDataResponse {
+	profile := archive.Profile
+	res := &userv1.ExportUserDataResponse{
+		ExportedAt: timestamppb.New(archive.ExportedAt),
+		Id:         profile.ID.String(),
+		Profile: &userv1.UserSnapshot{
+			FirstName:  profile.FirstName,
+			SecondName: profile.SecondName,
+			LastName:   profile.LastName,
+			Age:        int32(profile.Age),
+			Email:      profile.Email,
+			Phone:      profile.Phone,
+			Role:       string(profile.Role),
+			CreatedAt:  timestamppb.New(profile.CreatedAt),
+			UpdatedAt:  timestamppb.New(profile.UpdatedAt),
+		},
+		// Архив содержит все хранимые данные, кроме пароля
+		History:  fromUserHistoryEntries(archive.History, entity.UserAudienceAdmin),
+		Audit:    fromUserHistoryEntries(archive.Audit, entity.UserAudienceAdmin),
+		Sessions: fromUserSessions(archive.Sessions),
+	}
+	if profile.ErasedAt != nil {
+		res.ErasedAt = timestamppb.New(*profile.ErasedAt)
+	}
+
+	return res
+}
+
+// ToHistoryCursor разбирает токен страницы истории, пустой токен - первая страница
+func (u *userPresenter) ToHistoryCursor(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	cursor, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || cursor < 0 {
+		return 0, fmt.Errorf("invalid page token %q", token)
+	}
+
+	return cursor, nil
+}
+
+func fromUserHistoryEntries(entries []*entity.UserHistoryEntry, audience entity.UserAudience) []*userv1.UserHistoryEntry {
+	res := make([]*userv1.UserHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		historyEntry := &userv1.UserHistoryEntry{
+			Id:        entry.ID,
+			UserId:    entry.UserID.String(),
+			Action:    string(entry.Action),
+			Before:    fromUserSnapshot(entry.Before, audience),
+			After:     fromUserSnapshot(entry.After, audience),
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		}
+		if entry.ActorID != nil {
+			historyEntry.ActorId = entry.ActorID.String()
+		}
+		res = append(res, historyEntry)
+	}
+
+	return res
+}
+
+func fromUserSessions(sessions []*entity.UserSession) []*userv1.UserSession {
+	res := make([]*userv1.UserSession, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, &userv1.UserSession{
+			Id:        session.ID,
+			IssuedAt:  timestamppb.New(session.IssuedAt),
+			ExpiresAt: timestamppb.New(session.ExpiresAt),
+		})
+	}
+
+	return res
+}
+
+// fromUserSnapshot возвращает поля снимка, доступные аудитории, по тем же правилам, что и FromUser
+func fromUserSnapshot(snapshot *entity.UserSnapshot, audience entity.UserAudience) *userv1.UserSnapshot {
+	if snapshot == nil {
+		return nil
+	}
+
+	res := &userv1.UserSnapshot{
+		FirstName:  snapshot.FirstName,
+		SecondName: snapshot.SecondName,
+		LastName:   snapshot.LastName,
+		CreatedAt:  timestamppb.New(snapshot.CreatedAt),
+	}
+	if audience == entity.UserAudienceOther {
+		return res
+	}
+
+	res.Age = int32(snapshot.Age)
+	res.Email = snapshot.Email
+	res.Phone = snapshot.Phone
+	res.UpdatedAt = timestamppb.New(snapshot.UpdatedAt)
+	if audience != entity.UserAudienceAdmin {
+		return res
+	}
+
+	res.Role = string(snapshot.Role)
+
+	return res
+}
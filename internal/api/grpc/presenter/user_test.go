@@ -0,0 +1,145 @@
+package presenter
+
+import (
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_userPresenter_FromUser(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	user := &entity.User{
+		ID:         &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")},
+		FirstName:  "John",
+		SecondName: "DoeD",
+		LastName:   "Doe",
+		Password:   "qwerty1234",
+		Age:        30,
+		Email:      "doe@example.com",
+		Phone:      "+1111111111",
+		Role:       entity.RoleUser,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}
+	public := &userv1.User{
+		Id:         "4a6e104d-9d7f-45ff-8de6-37993d709522",
+		FirstName:  "John",
+		SecondName: "DoeD",
+		LastName:   "Doe",
+		CreatedAt:  timestamppb.New(createdAt),
+	}
+	self := proto.Clone(public).(*userv1.User)
+	self.Age = 30
+	self.Email = "doe@example.com"
+	self.Phone = "+1111111111"
+	self.UpdatedAt = timestamppb.New(updatedAt)
+	admin := proto.Clone(self).(*userv1.User)
+	admin.Role = "user"
+
+	tests := []struct {
+		name     string
+		audience entity.UserAudience
+		want     *userv1.User
+	}{
+		{name: "other user", audience: entity.UserAudienceOther, want: public},
+		{name: "own profile", audience: entity.UserAudienceSelf, want: self},
+		{name: "admin", audience: entity.UserAudienceAdmin, want: admin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUserPresenter().FromUser(user, tt.audience)
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FromUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserAudienceOf(t *testing.T) {
+	user := &entity.User{ID: &entity.UserID{Id: uuid.New()}, Role: entity.RoleUser}
+	other := &entity.User{ID: &entity.UserID{Id: uuid.New()}, Role: entity.RoleUser}
+	admin := &entity.User{ID: &entity.UserID{Id: uuid.New()}, Role: entity.RoleAdmin}
+
+	tests := []struct {
+		name   string
+		viewer *entity.User
+		want   entity.UserAudience
+	}{
+		{name: "anonymous", viewer: nil, want: entity.UserAudienceOther},
+		{name: "self", viewer: user, want: entity.UserAudienceSelf},
+		{name: "other user", viewer: other, want: entity.UserAudienceOther},
+		{name: "admin", viewer: admin, want: entity.UserAudienceAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entity.UserAudienceOf(tt.viewer, user); got != tt.want {
+				t.Errorf("UserAudienceOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userPresenter_FromUserHistoryPage(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	id := &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}
+	page := &entity.UserHistoryPage{
+		Entries: []*entity.UserHistoryEntry{{
+			ID:     1,
+			UserID: id,
+			Action: entity.UserHistoryUpdate,
+			After: &entity.UserSnapshot{
+				FirstName:  "John",
+				SecondName: "DoeD",
+				LastName:   "Doe",
+				Age:        30,
+				Email:      "doe@example.com",
+				Phone:      "+1111111111",
+				Role:       entity.RoleUser,
+				CreatedAt:  createdAt,
+				UpdatedAt:  updatedAt,
+			},
+			CreatedAt: updatedAt,
+		}},
+	}
+	public := &userv1.UserSnapshot{
+		FirstName:  "John",
+		SecondName: "DoeD",
+		LastName:   "Doe",
+		CreatedAt:  timestamppb.New(createdAt),
+	}
+	self := proto.Clone(public).(*userv1.UserSnapshot)
+	self.Age = 30
+	self.Email = "doe@example.com"
+	self.Phone = "+1111111111"
+	self.UpdatedAt = timestamppb.New(updatedAt)
+	admin := proto.Clone(self).(*userv1.UserSnapshot)
+	admin.Role = "user"
+
+	tests := []struct {
+		name     string
+		audience entity.UserAudience
+		want     *userv1.UserSnapshot
+	}{
+		{name: "other user", audience: entity.UserAudienceOther, want: public},
+		{name: "own history", audience: entity.UserAudienceSelf, want: self},
+		{name: "admin", audience: entity.UserAudienceAdmin, want: admin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUserPresenter().FromUserHistoryPage(page, tt.audience)
+			if len(got.GetEntries()) != 1 {
+				t.Fatalf("FromUserHistoryPage() entries = %v, want 1", got.GetEntries())
+			}
+			if after := got.GetEntries()[0].GetAfter(); !proto.Equal(after, tt.want) {
+				t.Errorf("FromUserHistoryPage() after = %v, want %v", after, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"go-test-grpc-http/internal/metrics"
+	"go-test-grpc-http/internal/ratelimit"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Сеть слушателя внутри процесса, см. DialInProcess
+const inProcessNetwork = "bufconn"
+
+// rateLimitInterceptor отклоняет вызовы сверх ограничения частоты запросов клиента. Клиент определяется
+// по пользователю в токене, а без него - по адресу соединения.
+// Вызовы внутри процесса (шлюз, Connect) уже учтены HTTP-сервером и не ограничиваются повторно.
+type rateLimitInterceptor struct {
+	limiter *ratelimit.Limiter
+}
+
+func NewRateLimitInterceptor(limiter *ratelimit.Limiter) *rateLimitInterceptor {
+	return &rateLimitInterceptor{
+		limiter: limiter,
+	}
+}
+
+func (r *rateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := r.allow(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (r *rateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := r.allow(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (r *rateLimitInterceptor) allow(ctx context.Context) error {
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if p.Addr.Network() == inProcessNetwork {
+			return nil
+		}
+		addr = p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+	}
+
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+
+	if r.limiter.Allow(ratelimit.ClientKey(authorization, addr)) {
+		return nil
+	}
+	metrics.RateLimited.WithLabelValues("grpc").Inc()
+
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+}
@@ -3,39 +3,62 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
 	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/middleware"
 	"go-test-grpc-http/internal/api/grpc/presenter"
-	"go-test-grpc-http/internal/db"
-	"go-test-grpc-http/internal/repository"
+	"go-test-grpc-http/internal/health"
+	"go-test-grpc-http/internal/ratelimit"
 	"go-test-grpc-http/internal/usecase"
 	"net"
+	"net/http"
 
-	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
-	"goa.design/goa/v3/grpc/middleware"
+	goa_middleware "goa.design/goa/v3/grpc/middleware"
 
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 )
 
+// Размер буфера соединений внутри процесса
+const inProcessBufferSize = 1 << 20
+
 type server struct {
 	addr   string
 	server *grpc.Server
-	db     *sqlx.DB
-	logger *zap.Logger
+	// inProcess слушатель для клиентов внутри процесса, например HTTP/JSON-шлюза
+	inProcess *bufconn.Listener
+	// userInteractor общий для всех транспортов сценарий работы с пользователями
+	userInteractor usecase.UserInteractor
+	health         *health.Registry
+	logger         *zap.Logger
 }
 
-func NewServer(addr string, db *sqlx.DB, logger *zap.Logger) *server {
+func NewServer(
+	addr string,
+	userInteractor usecase.UserInteractor,
+	health *health.Registry,
+	limiter *ratelimit.Limiter,
+	logger *zap.Logger,
+) *server {
 	grpcServer := &server{
-		addr:   addr,
-		db:     db,
-		logger: logger,
+		addr:           addr,
+		inProcess:      bufconn.Listen(inProcessBufferSize),
+		userInteractor: userInteractor,
+		health:         health,
+		logger:         logger,
 	}
 
 	recoveryHandler := func(p interface{}) (err error) {
@@ -46,30 +69,47 @@ func NewServer(addr string, db *sqlx.DB, logger *zap.Logger) *server {
 	}
 
 	interceptor := NewInterceptor()
+	metricsInterceptor := NewMetricsInterceptor()
+	traceTagsInterceptor := NewTraceTagsInterceptor()
+	validationInterceptor := NewValidationInterceptor()
+	rateLimitInterceptor := NewRateLimitInterceptor(limiter)
 
 	s := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			grpc_recovery.UnaryServerInterceptor(recoveryOpts...),
-			middleware.UnaryRequestID(
-				middleware.UseXRequestIDMetadataOption(true),
-				middleware.XRequestMetadataLimitOption(128),
+			otelgrpc.UnaryServerInterceptor(),
+			metricsInterceptor.Unary(),
+			rateLimitInterceptor.Unary(),
+			goa_middleware.UnaryRequestID(
+				goa_middleware.UseXRequestIDMetadataOption(true),
+				goa_middleware.XRequestMetadataLimitOption(128),
 			),
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			traceTagsInterceptor.Unary(),
 			grpc_zap.UnaryServerInterceptor(logger, grpc_zap.WithLevels(grpcServer.grpcCodeToZapLevel)),
 			interceptor.Unary(),
+			middleware.NewAuthMiddleware(userInteractor),
+			validationInterceptor.Unary(),
 		),
 		grpc.ChainStreamInterceptor(
 			grpc_recovery.StreamServerInterceptor(recoveryOpts...),
-			middleware.StreamRequestID(
-				middleware.UseXRequestIDMetadataOption(true),
-				middleware.XRequestMetadataLimitOption(128),
+			otelgrpc.StreamServerInterceptor(),
+			metricsInterceptor.Stream(),
+			rateLimitInterceptor.Stream(),
+			goa_middleware.StreamRequestID(
+				goa_middleware.UseXRequestIDMetadataOption(true),
+				goa_middleware.XRequestMetadataLimitOption(128),
 			),
 			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
+			traceTagsInterceptor.Stream(),
 			grpc_zap.StreamServerInterceptor(logger, grpc_zap.WithLevels(grpcServer.grpcCodeToZapLevel)),
 			interceptor.Stream(),
+			middleware.NewStreamAuthMiddleware(userInteractor),
+			validationInterceptor.Stream(),
 		),
 	)
 	grpcServer.server = s
+	grpcServer.registerServers()
 
 	return grpcServer
 }
@@ -80,22 +120,75 @@ func (s *server) Run(ctx context.Context) error {
 		return fmt.Errorf("grpc listen error: %w", err)
 	}
 
-	s.registerServers()
+	return s.Serve(ctx, lis)
+}
+
+// Serve обслуживает запросы на переданном слушателе до вызова Shutdown
+func (s *server) Serve(_ context.Context, lis net.Listener) error {
 	go func() {
-		<-ctx.Done()
-		err := s.Shutdown(ctx)
+		err := s.server.Serve(s.inProcess)
 		if err != nil {
-			s.logger.Fatal("can't shutdown grpc server", zap.Error(err))
-			return
+			s.logger.Error("grpc in-process listener error", zap.Error(err))
 		}
 	}()
 
 	return s.server.Serve(lis)
 }
 
-func (s *server) Shutdown(_ context.Context) error {
-	s.server.GracefulStop()
-	return nil
+// DialInProcess создает клиентское соединение с сервером без выхода в сеть.
+// Запросы проходят через ту же цепочку перехватчиков, что и внешние.
+func (s *server) DialInProcess(ctx context.Context) (*grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(ctx, "passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.inProcess.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// Продолжаем трассу HTTP-запроса, пришедшего через шлюз или Connect
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't dial in-process grpc server: %w", err)
+	}
+
+	return conn, nil
+}
+
+// WithGrpcWeb возвращает обработчик, который передает запросы gRPC-Web от браузеров серверу,
+// а остальные запросы - в next
+func (s *server) WithGrpcWeb(next http.Handler) http.Handler {
+	grpcWeb := grpcweb.WrapServer(s.server,
+		// Источники проверяются по тому же списку CORS_ORIGINS, что и в HTTP API
+		grpcweb.WithOriginFunc(config.AllowOrigin),
+		grpcweb.WithAllowedRequestHeaders([]string{"Authorization", "Accept-Language", "X-Request-ID", "X-Grpc-Web", "X-User-Agent"}),
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if grpcWeb.IsGrpcWebRequest(r) || grpcWeb.IsAcceptableGrpcCorsRequest(r) {
+			grpcWeb.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Shutdown дожидается завершения текущих запросов. Если срок ctx истек раньше,
+// оставшиеся соединения закрываются принудительно.
+func (s *server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		<-stopped
+		return fmt.Errorf("grpc server stopped forcibly: %w", ctx.Err())
+	}
 }
 
 func (s *server) grpcCodeToZapLevel(code codes.Code) zapcore.Level {
@@ -108,12 +201,17 @@ func (s *server) grpcCodeToZapLevel(code codes.Code) zapcore.Level {
 }
 
 func (s *server) registerServers() {
-	pgSource := db.NewSource(s.db)
-
-	userRepository := repository.NewUserRepository(pgSource)
-	userInteractor := usecase.NewUserInteractor(userRepository)
+	userInteractor := s.userInteractor
 	userPresenter := presenter.NewUserPresenter()
+	importPresenter := presenter.NewImportPresenter()
 	userv1.RegisterUserAPIServer(s.server, NewUserServer(userInteractor, userPresenter))
+	userv1.RegisterAdminAPIServer(s.server, NewAdminServer(userInteractor, userPresenter, importPresenter))
+	userv1.RegisterAuthAPIServer(s.server, NewAuthServer(userInteractor, userPresenter))
+	healthpb.RegisterHealthServer(s.server, NewHealthServer(s.health,
+		userv1.UserAPI_ServiceDesc.ServiceName,
+		userv1.AdminAPI_ServiceDesc.ServiceName,
+		userv1.AuthAPI_ServiceDesc.ServiceName,
+	))
 
 	// Серверная рефлексия
 	reflection.Register(s.server)
@@ -0,0 +1,137 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/middleware"
+	"go-test-grpc-http/internal/api/grpc/presenter"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"io"
+)
+
+type adminServer struct {
+	interactor      usecase.UserInteractor
+	userPresenter   presenter.UserPresenter
+	importPresenter presenter.ImportPresenter
+	userv1.UnimplementedAdminAPIServer
+}
+
+func NewAdminServer(interactor usecase.UserInteractor, userPresenter presenter.UserPresenter, importPresenter presenter.ImportPresenter) userv1.AdminAPIServer {
+	return &adminServer{
+		interactor:      interactor,
+		userPresenter:   userPresenter,
+		importPresenter: importPresenter,
+	}
+}
+
+func (s *adminServer) ImportUsers(stream userv1.AdminAPI_ImportUsersServer) error {
+	ctx := stream.Context()
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+
+	request, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return NewDomainApiError("import users error", errImportOptionsRequired)
+		}
+		return NewDomainApiError("import users error", err)
+	}
+	if request.GetOptions() == nil {
+		return NewDomainApiError("import users error", errImportOptionsRequired)
+	}
+
+	// Формат и размер пачки проверяются правилами из proto
+	opts := s.importPresenter.ToUserImportOptions(request.GetOptions())
+
+	report, err := s.interactor.Import(ctx, &importStreamReader{stream: stream}, opts)
+	if err != nil {
+		return NewDomainApiError("import users error", err)
+	}
+
+	return stream.SendAndClose(s.importPresenter.FromUserImportReport(report))
+}
+
+func (s *adminServer) ExportUserData(ctx context.Context, request *userv1.ExportUserDataRequest) (*userv1.ExportUserDataResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	userId := s.userPresenter.ToUserID(request.GetId())
+	if userId == nil {
+		return nil, NewDomainApiError("export user data error", errInvalidID)
+	}
+
+	archive, err := s.interactor.ExportData(ctx, userId)
+	if err != nil {
+		return nil, NewDomainApiError("export user data error", err)
+	}
+
+	return s.userPresenter.FromUserDataArchive(archive), nil
+}
+
+func (s *adminServer) EraseUser(ctx context.Context, request *userv1.EraseUserRequest) (*userv1.EraseUserResponse, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	userId := s.userPresenter.ToUserID(request.GetId())
+	if userId == nil {
+		return nil, NewDomainApiError("erase user error", errInvalidID)
+	}
+
+	err := s.interactor.Erase(ctx, userId)
+	if err != nil {
+		return nil, NewDomainApiError("erase user error", err)
+	}
+
+	return &userv1.EraseUserResponse{}, nil
+}
+
+// authorize проверяет, что вызывающий пользователь является незаблокированным администратором
+func (s *adminServer) authorize(ctx context.Context) error {
+	id, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return NewDomainApiError("authorize error", errNotAuthenticated)
+	}
+
+	user, err := s.interactor.GetById(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return NewDomainApiError("authorize error", domain.Unauthenticated("user is not found"))
+	}
+	if err != nil {
+		return NewDomainApiError("get user error", err)
+	}
+	if user.DisabledAt != nil {
+		return NewDomainApiError("authorize error", entity.ErrUserDisabled)
+	}
+	if user.Role != entity.RoleAdmin {
+		return NewDomainApiError("authorize error", domain.Forbidden("admin role is required"))
+	}
+
+	return nil
+}
+
+// importStreamReader читает части файла импорта из потока gRPC
+type importStreamReader struct {
+	stream userv1.AdminAPI_ImportUsersServer
+	buf    []byte
+}
+
+func (r *importStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		request, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = request.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/presenter"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/metrics"
+	"go-test-grpc-http/internal/usecase"
+)
+
+type authServer struct {
+	interactor usecase.UserInteractor
+	presenter  presenter.UserPresenter
+	userv1.UnimplementedAuthAPIServer
+}
+
+func NewAuthServer(interactor usecase.UserInteractor, presenter presenter.UserPresenter) userv1.AuthAPIServer {
+	return &authServer{
+		interactor: interactor,
+		presenter:  presenter,
+	}
+}
+
+func (s *authServer) SignUp(ctx context.Context, request *userv1.SignUpRequest) (*userv1.SignUpResponse, error) {
+	userId, err := s.interactor.Create(ctx, s.presenter.ToUserCreate(request.GetUser()))
+	if err != nil {
+		return nil, NewDomainApiError("sign up error", err)
+	}
+	metrics.SignUps.Inc()
+
+	token, err := entity.GenerateToken(userId).String()
+	if err != nil {
+		return nil, NewDomainApiError("sign up error", err)
+	}
+
+	return &userv1.SignUpResponse{
+		Token: token,
+	}, nil
+}
+
+func (s *authServer) SignIn(ctx context.Context, request *userv1.SignInRequest) (*userv1.SignInResponse, error) {
+	userId, err := s.interactor.SignIn(ctx, request.GetEmail())
+	if errors.Is(err, domain.ErrNotFound) {
+		metrics.FailedLogins.Inc()
+		return nil, NewDomainApiError("sign in error", domain.Unauthenticated("invalid credentials"))
+	}
+	if errors.Is(err, entity.ErrUserDisabled) {
+		metrics.FailedLogins.Inc()
+	}
+	if err != nil {
+		return nil, NewDomainApiError("sign in error", err)
+	}
+	metrics.SignIns.Inc()
+
+	token, err := entity.GenerateToken(userId).String()
+	if err != nil {
+		return nil, NewDomainApiError("sign in error", err)
+	}
+
+	return &userv1.SignInResponse{
+		Token: token,
+	}, nil
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"go-test-grpc-http/internal/health"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Период опроса проверок в Watch
+const healthWatchInterval = 5 * time.Second
+
+// healthServer реализует grpc.health.v1.Health поверх проверок готовности приложения.
+//
+//	Пустое имя и имена сервисов API возвращают общее состояние, имя подсистемы - состояние этой подсистемы.
+type healthServer struct {
+	registry *health.Registry
+	services map[string]struct{}
+	healthpb.UnimplementedHealthServer
+}
+
+func NewHealthServer(registry *health.Registry, services ...string) healthpb.HealthServer {
+	s := &healthServer{
+		registry: registry,
+		services: map[string]struct{}{"": {}},
+	}
+	for _, service := range services {
+		s.services[service] = struct{}{}
+	}
+
+	return s
+}
+
+func (s *healthServer) Check(ctx context.Context, request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus, err := s.status(ctx, request.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	return &healthpb.HealthCheckResponse{
+		Status: servingStatus,
+	}, nil
+}
+
+func (s *healthServer) Watch(request *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		servingStatus, err := s.status(ctx, request.GetService())
+		if status.Code(err) == codes.NotFound {
+			servingStatus = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		} else if err != nil {
+			return err
+		}
+		if servingStatus != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+			last = servingStatus
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *healthServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	if _, ok := s.services[service]; ok {
+		return toServingStatus(s.registry.Ready(ctx).Status), nil
+	}
+
+	result, ok := s.registry.Check(ctx, service)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, status.Errorf(codes.NotFound, "unknown service %q", service)
+	}
+
+	return toServingStatus(result.Status), nil
+}
+
+func toServingStatus(s string) healthpb.HealthCheckResponse_ServingStatus {
+	if s == health.StatusServing {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"go-test-grpc-http/internal/health"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func Test_healthServer_Check(t *testing.T) {
+	registry := health.NewRegistry()
+	registry.Register("db", func(context.Context) error { return nil })
+	registry.Register("migrations", func(context.Context) error { return errors.New("dirty") })
+	s := NewHealthServer(registry, "servertemplate.user.v1.UserAPI")
+
+	tests := []struct {
+		name     string
+		service  string
+		want     healthpb.HealthCheckResponse_ServingStatus
+		wantCode codes.Code
+	}{
+		{name: "overall", service: "", want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "api service", service: "servertemplate.user.v1.UserAPI", want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "serving subsystem", service: "db", want: healthpb.HealthCheckResponse_SERVING},
+		{name: "failed subsystem", service: "migrations", want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "unknown service", service: "unknown", wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Check() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err == nil && got.GetStatus() != tt.want {
+				t.Errorf("Check() status = %v, want %v", got.GetStatus(), tt.want)
+			}
+		})
+	}
+}
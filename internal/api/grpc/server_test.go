@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func Test_server_WithGrpcWeb_origin(t *testing.T) {
+	var cfg config.Config
+	if err := config.Parse(config.NewParser(&cfg), &cfg, nil); err != nil {
+		t.Fatalf("can't parse config: %v", err)
+	}
+	cfg.Cors.Origins = []string{"https://app.example.com"}
+	config.SetAppConfig(&cfg)
+
+	s := &server{server: grpc.NewServer()}
+	userv1.RegisterUserAPIServer(s.server, userv1.UnimplementedUserAPIServer{})
+	handler := s.WithGrpcWeb(http.NotFoundHandler())
+
+	tests := []struct {
+		name   string
+		origin string
+		want   string
+	}{
+		{name: "allowed origin", origin: "https://app.example.com", want: "https://app.example.com"},
+		{name: "unknown origin", origin: "https://evil.example.com", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/servertemplate.user.v1.UserAPI/GetById", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "x-grpc-web")
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.want {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
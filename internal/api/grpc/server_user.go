@@ -2,11 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/middleware"
 	"go-test-grpc-http/internal/api/grpc/presenter"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
 	"go-test-grpc-http/internal/usecase"
-
-	"google.golang.org/grpc/codes"
 )
 
 type userServer struct {
@@ -25,56 +28,183 @@ func NewUserServer(interactor usecase.UserInteractor, presenter presenter.UserPr
 func (s *userServer) GetById(ctx context.Context, request *userv1.GetByIdRequest) (*userv1.GetByIdResponse, error) {
 	userId := s.presenter.ToUserID(request.GetId())
 	if userId == nil {
-		return nil, NewApiError(codes.Internal, "get user error: id is invalid")
+		return nil, NewDomainApiError("get user error", errInvalidID)
 	}
 	user, err := s.interactor.GetById(ctx, userId)
 	if err != nil {
-		return nil, NewApiError(codes.Internal, "get user error: %v", err)
+		return nil, NewDomainApiError("get user error", err)
+	}
+
+	audience, err := s.audience(ctx, user)
+	if err != nil {
+		return nil, NewDomainApiError("get user error", err)
 	}
 
 	return &userv1.GetByIdResponse{
-		User: s.presenter.FromUser(user),
+		User: s.presenter.FromUser(user, audience),
 	}, nil
 }
 
 func (s *userServer) GetByEmail(ctx context.Context, request *userv1.GetByEmailRequest) (*userv1.GetByEmailResponse, error) {
 	user, err := s.interactor.GetByEmail(ctx, request.GetEmail())
 	if err != nil {
-		return nil, NewApiError(codes.Internal, "get user error: %v", err)
+		return nil, NewDomainApiError("get user error", err)
+	}
+
+	audience, err := s.audience(ctx, user)
+	if err != nil {
+		return nil, NewDomainApiError("get user error", err)
 	}
 
 	return &userv1.GetByEmailResponse{
-		User: s.presenter.FromUser(user),
+		User: s.presenter.FromUser(user, audience),
 	}, nil
 }
 
 func (s *userServer) Update(ctx context.Context, request *userv1.UpdateRequest) (*userv1.UpdateResponse, error) {
 	userId := s.presenter.ToUserID(request.GetId())
 	if userId == nil {
-		return nil, NewApiError(codes.Internal, "get user error: id is invalid")
+		return nil, NewDomainApiError("update user error", errInvalidID)
+	}
+
+	audience, err := s.ownerAudience(ctx, userId, errUserForbidden)
+	if err != nil {
+		return nil, NewDomainApiError("update user error", err)
 	}
 
 	user := s.presenter.ToUserCreate(request.User)
 
 	userDB, err := s.interactor.Update(ctx, userId, user)
 	if err != nil {
-		return nil, NewApiError(codes.Internal, "get user error", err)
+		return nil, NewDomainApiError("update user error", err)
 	}
 
 	return &userv1.UpdateResponse{
-		User: s.presenter.FromUser(userDB),
+		User: s.presenter.FromUser(userDB, audience),
 	}, nil
 }
 
 func (s *userServer) Delete(ctx context.Context, request *userv1.DeleteRequest) (*userv1.DeleteResponse, error) {
 	userId := s.presenter.ToUserID(request.GetId())
 	if userId == nil {
-		return nil, NewApiError(codes.Internal, "get user error: id is invalid")
+		return nil, NewDomainApiError("delete user error", errInvalidID)
+	}
+	if _, err := s.ownerAudience(ctx, userId, errUserForbidden); err != nil {
+		return nil, NewDomainApiError("delete user error", err)
 	}
 	err := s.interactor.Delete(ctx, userId)
 	if err != nil {
-		return nil, NewApiError(codes.Internal, "get user error: %v", err)
+		return nil, NewDomainApiError("delete user error", err)
 	}
 
 	return &userv1.DeleteResponse{}, nil
 }
+
+func (s *userServer) GetHistory(ctx context.Context, request *userv1.GetHistoryRequest) (*userv1.GetHistoryResponse, error) {
+	userId := s.presenter.ToUserID(request.GetId())
+	if userId == nil {
+		return nil, NewDomainApiError("get history error", errInvalidID)
+	}
+	cursor, err := s.presenter.ToHistoryCursor(request.GetPageToken())
+	if err != nil {
+		return nil, NewDomainApiError("get history error", invalidField("page_token", err))
+	}
+
+	audience, err := s.ownerAudience(ctx, userId, errHistoryForbidden)
+	if err != nil {
+		return nil, NewDomainApiError("get history error", err)
+	}
+
+	page, err := s.interactor.GetHistory(ctx, userId, cursor, int(request.GetPageSize()))
+	if err != nil {
+		return nil, NewDomainApiError("get history error", err)
+	}
+
+	return s.presenter.FromUserHistoryPage(page, audience), nil
+}
+
+func (s *userServer) GetMe(ctx context.Context, _ *userv1.GetMeRequest) (*userv1.GetMeResponse, error) {
+	userId, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, NewDomainApiError("get user error", errNotAuthenticated)
+	}
+	user, err := s.interactor.GetById(ctx, userId)
+	if err != nil {
+		return nil, NewDomainApiError("get user error", err)
+	}
+
+	return &userv1.GetMeResponse{
+		User: s.presenter.FromUser(user, entity.UserAudienceOf(user, user)),
+	}, nil
+}
+
+func (s *userServer) UpdateMe(ctx context.Context, request *userv1.UpdateMeRequest) (*userv1.UpdateMeResponse, error) {
+	userId, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, NewDomainApiError("update user error", errNotAuthenticated)
+	}
+	user, err := s.interactor.Update(ctx, userId, s.presenter.ToUserUpdate(request.GetUser()))
+	if err != nil {
+		return nil, NewDomainApiError("update user error", err)
+	}
+
+	return &userv1.UpdateMeResponse{
+		User: s.presenter.FromUser(user, entity.UserAudienceOf(user, user)),
+	}, nil
+}
+
+func (s *userServer) DeleteMe(ctx context.Context, _ *userv1.DeleteMeRequest) (*userv1.DeleteMeResponse, error) {
+	userId, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return nil, NewDomainApiError("delete user error", errNotAuthenticated)
+	}
+	err := s.interactor.Delete(ctx, userId)
+	if err != nil {
+		return nil, NewDomainApiError("delete user error", err)
+	}
+
+	return &userv1.DeleteMeResponse{}, nil
+}
+
+// audience определяет по роли вызывающего, какие поля user ему доступны
+func (s *userServer) audience(ctx context.Context, user *entity.User) (entity.UserAudience, error) {
+	id, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return entity.UserAudienceOther, nil
+	}
+
+	viewer, err := s.interactor.GetById(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return entity.UserAudienceOther, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't get caller: %w", err)
+	}
+
+	return entity.UserAudienceOf(viewer, user), nil
+}
+
+// ownerAudience определяет, какие поля пользователя id доступны вызывающему. Доступ есть только
+// у самого пользователя и администраторов, остальным возвращается forbidden. Пользователь может быть
+// уже удален, поэтому загружается только вызывающий.
+func (s *userServer) ownerAudience(ctx context.Context, id *entity.UserID, forbidden error) (entity.UserAudience, error) {
+	callerID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return "", errNotAuthenticated
+	}
+
+	caller, err := s.interactor.GetById(ctx, callerID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.Unauthenticated("user is not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't get caller: %w", err)
+	}
+
+	audience := entity.UserAudienceOf(caller, &entity.User{ID: id})
+	if audience == entity.UserAudienceOther {
+		return "", forbidden
+	}
+
+	return audience, nil
+}
@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/api/grpc/middleware"
+	"go-test-grpc-http/internal/api/grpc/presenter"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_userServer_UpdateDelete_access(t *testing.T) {
+	owner := &entity.User{ID: &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}, Role: entity.RoleUser}
+	other := &entity.User{ID: &entity.UserID{Id: uuid.MustParse("a1f0c5e4-3f5b-4d8e-9a7c-2b6d8e4f1c3a")}, Role: entity.RoleUser}
+	admin := &entity.User{ID: &entity.UserID{Id: uuid.MustParse("0b8e7c2d-5a4f-4e3b-8c1d-9f6a2e5b7d40")}, Role: entity.RoleAdmin}
+
+	tests := []struct {
+		name     string
+		caller   *entity.User
+		wantCode codes.Code
+	}{
+		{name: "own user", caller: owner, wantCode: codes.OK},
+		{name: "admin", caller: admin, wantCode: codes.OK},
+		{name: "other user", caller: other, wantCode: codes.PermissionDenied},
+		{name: "anonymous", wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			interactor := usecase.NewMockUserInteractor(ctrl)
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = middleware.ContextWithUserID(ctx, tt.caller.ID)
+				interactor.EXPECT().GetById(gomock.Any(), tt.caller.ID).Return(tt.caller, nil).Times(2)
+			}
+			if tt.wantCode == codes.OK {
+				interactor.EXPECT().Update(gomock.Any(), owner.ID, gomock.Any()).Return(owner, nil)
+				interactor.EXPECT().Delete(gomock.Any(), owner.ID).Return(nil)
+			}
+
+			s := NewUserServer(interactor, presenter.NewUserPresenter())
+			_, err := s.Update(ctx, &userv1.UpdateRequest{Id: owner.ID.Id.String(), User: &userv1.UserCreate{}})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Update() error = %v, want code %v", err, tt.wantCode)
+			}
+			_, err = s.Delete(ctx, &userv1.DeleteRequest{Id: owner.ID.Id.String()})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Delete() error = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// traceTagsInterceptor добавляет ID трассы и спана в теги запроса, которые grpc_zap пишет в лог
+type traceTagsInterceptor struct{}
+
+func NewTraceTagsInterceptor() *traceTagsInterceptor {
+	return &traceTagsInterceptor{}
+}
+
+func (t *traceTagsInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		setTraceTags(ctx)
+		return handler(ctx, req)
+	}
+}
+
+func (t *traceTagsInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		setTraceTags(ss.Context())
+		return handler(srv, ss)
+	}
+}
+
+func setTraceTags(ctx context.Context) {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return
+	}
+
+	grpc_ctxtags.Extract(ctx).
+		Set("trace_id", spanCtx.TraceID().String()).
+		Set("span_id", spanCtx.SpanID().String())
+}
@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"go-test-grpc-http/internal/domain"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc"
+)
+
+// validatorAll сообщение с правилами protoc-gen-validate
+type validatorAll interface {
+	ValidateAll() error
+}
+
+// validationError ошибка проверки поля, сгенерированная protoc-gen-validate
+type validationError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// validationMultiError все ошибки проверки сообщения, сгенерированные protoc-gen-validate
+type validationMultiError interface {
+	AllErrors() []error
+}
+
+// errRequestInvalid сообщение ошибки, когда запрос не прошел проверку правил из proto
+const errRequestInvalid = "request is invalid"
+
+type validationInterceptor struct {
+}
+
+func NewValidationInterceptor() *validationInterceptor {
+	return &validationInterceptor{}
+}
+
+// Unary проверяет запрос правилами из proto и возвращает InvalidArgument со всеми нарушениями
+func (i *validationInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		if err := validate(req); err != nil {
+			return nil, NewDomainApiError("validation error", err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream проверяет каждое сообщение потока правилами из proto
+func (i *validationInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		wrapped := &validatingServerStream{ServerStream: ss}
+		err := handler(srv, wrapped)
+		// Обработчик мог обернуть ошибку проверки, поэтому в ответ возвращается исходная
+		if wrapped.err != nil {
+			return NewDomainApiError("validation error", wrapped.err)
+		}
+
+		return err
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+	err error
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := validate(m); err != nil {
+		s.err = err
+		return err
+	}
+
+	return nil
+}
+
+// validate вызывает ValidateAll и собирает нарушения по всем полям, включая вложенные сообщения
+func validate(msg interface{}) error {
+	v, ok := msg.(validatorAll)
+	if !ok {
+		return nil
+	}
+
+	err := v.ValidateAll()
+	if err == nil {
+		return nil
+	}
+
+	return domain.InvalidFields(errRequestInvalid, fieldViolations("", err)...)
+}
+
+func fieldViolations(prefix string, err error) []domain.Violation {
+	if multi, ok := err.(validationMultiError); ok {
+		var res []domain.Violation
+		for _, e := range multi.AllErrors() {
+			res = append(res, fieldViolations(prefix, e)...)
+		}
+		return res
+	}
+
+	fieldErr, ok := err.(validationError)
+	if !ok {
+		return []domain.Violation{{Field: prefix, Description: err.Error()}}
+	}
+
+	field := toSnakeCase(fieldErr.Field())
+	if prefix != "" {
+		field = prefix + "." + field
+	}
+
+	// Для вложенных сообщений причина содержит ошибки их полей
+	switch cause := fieldErr.Cause(); cause.(type) {
+	case validationMultiError, validationError:
+		return fieldViolations(field, cause)
+	}
+
+	return []domain.Violation{{Field: field, Description: fieldErr.Reason()}}
+}
+
+// toSnakeCase переводит имя поля Go (PageSize) в имя поля proto (page_size)
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"errors"
+	userv1 "go-test-grpc-http/internal/api/grpc/gen/servertemplate/user/v1"
+	"go-test-grpc-http/internal/domain"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func Test_validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        interface{}
+		wantFields []string
+	}{
+		{
+			name: "valid request",
+			msg: &userv1.UpdateRequest{
+				Id: "4a6e104d-9d7f-45ff-8de6-37993d709522",
+				User: &userv1.UserCreate{
+					LastName:  "Иванов",
+					FirstName: "Иван",
+					Age:       30,
+					Password:  "secret",
+					Email:     "ivan@example.com",
+					Phone:     "+79991234567",
+				},
+			},
+		},
+		{
+			name: "every violation is reported",
+			msg: &userv1.UpdateRequest{
+				Id: "not-a-uuid",
+				User: &userv1.UserCreate{
+					LastName: "Иванов",
+					Age:      200,
+					Password: "secret",
+					Email:    "ivan",
+					Phone:    "89991234567",
+				},
+			},
+			wantFields: []string{"id", "user.age", "user.email", "user.first_name", "user.phone"},
+		},
+		{
+			name:       "required message",
+			msg:        &userv1.SignUpRequest{},
+			wantFields: []string{"user"},
+		},
+		{
+			name:       "page size out of range",
+			msg:        &userv1.GetHistoryRequest{Id: "4a6e104d-9d7f-45ff-8de6-37993d709522", PageSize: 101},
+			wantFields: []string{"page_size"},
+		},
+		{
+			name: "message without rules",
+			msg:  struct{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.msg)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Errorf("validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrInvalid) {
+				t.Fatalf("validate() error = %v, want %v", err, domain.ErrInvalid)
+			}
+
+			var fields []string
+			for _, violation := range domain.ViolationsOf(err) {
+				fields = append(fields, violation.Field)
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("validate() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
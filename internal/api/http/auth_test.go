@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/api/http/middlewares"
+	"go-test-grpc-http/internal/db"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/repository"
+	"go-test-grpc-http/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestAdminRoutes_disabledAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var cfg config.Config
+	if err := config.Parse(config.NewParser(&cfg), &cfg, nil); err != nil {
+		t.Fatalf("can't parse config: %v", err)
+	}
+	config.SetAppConfig(&cfg)
+
+	ctx := context.Background()
+	interactor := usecase.NewUserInteractor(repository.NewUserRepository(db.NewMemorySource()))
+	id, err := interactor.Create(ctx, &entity.UserCreate{
+		FirstName:  "Admin",
+		SecondName: "Admin",
+		LastName:   "Admin",
+		Password:   "qwerty1234",
+		Age:        30,
+		Email:      "admin@example.com",
+		Phone:      "+1111111111",
+		Role:       entity.RoleAdmin,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	token, err := entity.GenerateToken(id).String()
+	if err != nil {
+		t.Fatalf("can't generate token: %v", err)
+	}
+
+	engine := gin.New()
+	engine.Use(middlewares.NewErrorMiddleware(zap.NewNop()))
+	for _, version := range apiVersions(&cfg) {
+		mountVersion(engine, version, apiDependencies{userInteractor: interactor})
+	}
+	exportData := func() int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, V1BasePath+"/admin/users/id/"+id.Id.String()+"/export", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := exportData(); code != http.StatusOK {
+		t.Fatalf("status before disable = %d, want %d", code, http.StatusOK)
+	}
+
+	if _, err := interactor.SetDisabled(ctx, id, true); err != nil {
+		t.Fatalf("SetDisabled() error = %v", err)
+	}
+	// Ранее выданный токен еще не истек, но заблокированный администратор теряет доступ
+	if code := exportData(); code != http.StatusForbidden {
+		t.Errorf("status after disable = %d, want %d", code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"go-test-grpc-http/internal/api/http/middlewares"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GRPCHandlers обработчики, передающие запросы HTTP gRPC-сервисам. Nil-обработчики не подключаются.
+type GRPCHandlers struct {
+	// Gateway HTTP/JSON-шлюз, маршруты которого заданы аннотациями google.api.http
+	Gateway http.Handler
+	// Connect обработчик протокола Connect
+	Connect http.Handler
+}
+
+// mountGateway подключает HTTP/JSON-шлюз к gRPC-сервисам по префиксу prefix
+// вместе с описанием OpenAPI v2, построенным из .proto
+func mountGateway(engine *gin.Engine, prefix string, gateway http.Handler) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	handler := http.StripPrefix(prefix, gateway)
+
+	group := engine.Group(prefix)
+	group.GET("/openapi.json", func(c *gin.Context) {
+		c.File("docs/gateway.swagger.json")
+	})
+	group.Any("/v1/*path", forwardTo(handler))
+}
+
+// mountConnect подключает обработчик протокола Connect по префиксу prefix
+func mountConnect(engine *gin.Engine, prefix string, connect http.Handler) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	engine.Group(prefix).Any("/*procedure", forwardTo(http.StripPrefix(prefix, connect)))
+}
+
+func forwardTo(handler http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Передаем в gRPC тот же ID запроса, что вернется клиенту
+		c.Request.Header.Set(middlewares.RequestIDHeader, c.GetString("request-id"))
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"fmt"
+	"go-test-grpc-http/internal/api/http/presenter"
+	"go-test-grpc-http/internal/api/http/problem"
+	_ "go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type adminHandlers struct {
+	interactor      usecase.UserInteractor
+	exportJobs      usecase.ExportJobInteractor
+	userPresenter   presenter.UserPresenter
+	importPresenter presenter.ImportPresenter
+	exportPresenter presenter.ExportPresenter
+}
+
+func NewAdminHandlers(
+	interactor usecase.UserInteractor,
+	exportJobs usecase.ExportJobInteractor,
+	userPresenter presenter.UserPresenter,
+	importPresenter presenter.ImportPresenter,
+	exportPresenter presenter.ExportPresenter,
+) *adminHandlers {
+	return &adminHandlers{
+		interactor:      interactor,
+		exportJobs:      exportJobs,
+		userPresenter:   userPresenter,
+		importPresenter: importPresenter,
+		exportPresenter: exportPresenter,
+	}
+}
+
+// ImportUsersHandler godoc
+// @Summary Массовый импорт пользователей
+// @Description Импорт пользователей из CSV (с заголовком) или NDJSON. Каждая строка проверяется отдельно,
+// @Description в ответе возвращается результат по каждой строке. Доступно только администраторам.
+// @Tags Admin
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string false "Формат данных: csv, ndjson. По умолчанию определяется по Content-Type"
+// @Param mode query string false "Поведение при ошибках: all-or-nothing (по умолчанию), best-effort"
+// @Param dry_run query bool false "Проверить данные без сохранения"
+// @Param batch_size query int false "Количество строк в одной транзакции"
+// @Param request body string true "Файл импорта"
+// @Security JwtAuth
+// @Success 200 {object} view.UserImportReportView "Отчет об импорте"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/import [post]
+func (h *adminHandlers) ImportUsersHandler(c *gin.Context) {
+	opts, err := parseImportOptions(c)
+	if err != nil {
+		problem.AbortWithStatus(c, http.StatusBadRequest, fmt.Errorf("invalid import options: %w", err))
+		return
+	}
+
+	report, err := h.interactor.Import(c.Request.Context(), c.Request.Body, opts)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't import users: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.importPresenter.ToUserImportReportView(report))
+}
+
+// ExportUsersHandler godoc
+// @Summary Выгрузка пользователей
+// @Description Потоковая выгрузка пользователей в CSV или NDJSON (chunked transfer). Пароль не выгружается.
+// @Description Доступно только администраторам.
+// @Tags Admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "Формат данных: csv (по умолчанию), ndjson"
+// @Param columns query string false "Колонки через запятую: id, first_name, second_name, last_name, age, email, phone, role"
+// @Param email query string false "Подстрока электронной почты"
+// @Param name query string false "Подстрока имени, фамилии или отчества"
+// @Param role query string false "Роль: user, admin"
+// @Param min_age query int false "Минимальный возраст"
+// @Param max_age query int false "Максимальный возраст"
+// @Security JwtAuth
+// @Success 200 {string} string "Файл выгрузки"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 422 {object} view.ProblemView "Некорректные параметры выгрузки"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/export [get]
+func (h *adminHandlers) ExportUsersHandler(c *gin.Context) {
+	opts, err := parseExportOptions(c)
+	if err != nil {
+		problem.AbortWithStatus(c, http.StatusBadRequest, fmt.Errorf("invalid export options: %w", err))
+		return
+	}
+
+	c.Header("Content-Type", exportContentType(opts.Format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, opts.Format))
+
+	_, err = h.interactor.Export(c.Request.Context(), c.Writer, opts)
+	if err != nil {
+		// После начала передачи статус ответа изменить уже нельзя
+		if c.Writer.Written() {
+			c.Error(fmt.Errorf("can't export users: %w", err))
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		abortWithError(c, fmt.Errorf("can't export users: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// StartExportJobHandler godoc
+// @Summary Запуск фоновой выгрузки пользователей
+// @Description Запускает выгрузку пользователей в файл на сервере. Параметры совпадают с синхронной выгрузкой.
+// @Description Доступно только администраторам.
+// @Tags Admin
+// @Produce json
+// @Param format query string false "Формат данных: csv (по умолчанию), ndjson"
+// @Param columns query string false "Колонки через запятую: id, first_name, second_name, last_name, age, email, phone, role"
+// @Param email query string false "Подстрока электронной почты"
+// @Param name query string false "Подстрока имени, фамилии или отчества"
+// @Param role query string false "Роль: user, admin"
+// @Param min_age query int false "Минимальный возраст"
+// @Param max_age query int false "Максимальный возраст"
+// @Security JwtAuth
+// @Success 202 {object} view.ExportJobView "Задача выгрузки"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 422 {object} view.ProblemView "Некорректные параметры выгрузки"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/export/jobs [post]
+func (h *adminHandlers) StartExportJobHandler(c *gin.Context) {
+	opts, err := parseExportOptions(c)
+	if err != nil {
+		problem.AbortWithStatus(c, http.StatusBadRequest, fmt.Errorf("invalid export options: %w", err))
+		return
+	}
+
+	job, err := h.exportJobs.Start(c.Request.Context(), opts)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't start export: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, h.exportPresenter.ToExportJobView(job))
+}
+
+// GetExportJobHandler godoc
+// @Summary Статус фоновой выгрузки пользователей
+// @Description Возвращает состояние задачи выгрузки. Доступно только администраторам.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "ID задачи"
+// @Security JwtAuth
+// @Success 200 {object} view.ExportJobView "Задача выгрузки"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 404 {object} view.ProblemView "Задача не найдена"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/export/jobs/{id} [get]
+func (h *adminHandlers) GetExportJobHandler(c *gin.Context) {
+	job, err := h.exportJobs.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't get export job: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.exportPresenter.ToExportJobView(job))
+}
+
+// DownloadExportJobHandler godoc
+// @Summary Скачивание результата фоновой выгрузки
+// @Description Возвращает файл завершенной задачи выгрузки. Доступно только администраторам.
+// @Tags Admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param id path string true "ID задачи"
+// @Security JwtAuth
+// @Success 200 {string} string "Файл выгрузки"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 404 {object} view.ProblemView "Задача не найдена"
+// @Failure 409 {object} view.ProblemView "Выгрузка не завершена"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/export/jobs/{id}/file [get]
+func (h *adminHandlers) DownloadExportJobHandler(c *gin.Context) {
+	job, err := h.exportJobs.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't get export job: %w", err))
+		return
+	}
+
+	if job.Status != entity.ExportJobStatusDone {
+		abortWithError(c, domain.Conflict(fmt.Sprintf("export job is %s", job.Status)))
+		return
+	}
+
+	if _, err := os.Stat(job.Path); err != nil {
+		abortWithError(c, fmt.Errorf("can't stat export file: %w: %v", domain.NotFound("export file is unavailable"), err))
+		return
+	}
+
+	c.Header("Content-Type", exportContentType(job.Options.Format))
+	c.FileAttachment(job.Path, fmt.Sprintf("users.%s", job.Options.Format))
+}
+
+// ExportUserDataHandler godoc
+// @Summary Выгрузка всех данных пользователя
+// @Description Возвращает JSON-архив со всеми данными, хранимыми о пользователе: профиль, история изменений
+// @Description и действия пользователя. Список sessions всегда пуст: записи о сеансах не ведутся.
+// @Description Доступно только администраторам.
+// @Tags Admin
+// @Produce json
+// @Param id path string true "Уникальный идентификатор пользователя (UUID)"
+// @Security JwtAuth
+// @Success 200 {object} view.UserDataArchiveView "Архив данных пользователя"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/id/{id}/export [get]
+func (h *adminHandlers) ExportUserDataHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortWithError(c, invalidParam("id", err))
+		return
+	}
+
+	writeUserDataArchive(c, h.interactor, h.userPresenter, &entity.UserID{Id: id})
+}
+
+// EraseUserHandler godoc
+// @Summary Удаление персональных данных пользователя
+// @Description Обезличивает пользователя по запросу на удаление данных: персональные данные в профиле и истории
+// @Description изменений затираются, записи и связи между ними сохраняются. Доступно только администраторам.
+// @Tags Admin
+// @Produce plain
+// @Param id path string true "Уникальный идентификатор пользователя (UUID)"
+// @Security JwtAuth
+// @Success 204 "Персональные данные удалены"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /admin/users/id/{id}/erase [post]
+func (h *adminHandlers) EraseUserHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortWithError(c, invalidParam("id", err))
+		return
+	}
+
+	eraseUser(c, h.interactor, &entity.UserID{Id: id})
+}
+
+func parseExportOptions(c *gin.Context) (*entity.UserExportOptions, error) {
+	opts := &entity.UserExportOptions{
+		Format: entity.DataFormatCSV,
+	}
+
+	switch format := c.Query("format"); format {
+	case "":
+	case string(entity.DataFormatCSV), string(entity.DataFormatNDJSON):
+		opts.Format = entity.DataFormat(format)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	if columns := c.Query("columns"); columns != "" {
+		for _, column := range strings.Split(columns, ",") {
+			opts.Columns = append(opts.Columns, strings.TrimSpace(column))
+		}
+	}
+
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	opts.Filter = *filter
+
+	return opts, nil
+}
+
+func exportContentType(format entity.DataFormat) string {
+	if format == entity.DataFormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func parseImportOptions(c *gin.Context) (*entity.UserImportOptions, error) {
+	opts := &entity.UserImportOptions{}
+
+	switch format := c.Query("format"); format {
+	case "":
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			opts.Format = entity.DataFormatCSV
+		case "application/x-ndjson", "application/ndjson", "application/jsonl":
+			opts.Format = entity.DataFormatNDJSON
+		default:
+			return nil, fmt.Errorf("can't detect format from content type %q", mediaType)
+		}
+	case string(entity.DataFormatCSV), string(entity.DataFormatNDJSON):
+		opts.Format = entity.DataFormat(format)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	switch mode := c.Query("mode"); mode {
+	case "", string(entity.ImportModeAllOrNothing), string(entity.ImportModeBestEffort):
+		opts.Mode = entity.ImportMode(mode)
+	default:
+		return nil, fmt.Errorf("unknown mode %q", mode)
+	}
+
+	if dryRun := c.Query("dry_run"); dryRun != "" {
+		value, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dry_run: %w", err)
+		}
+		opts.DryRun = value
+	}
+
+	if batchSize := c.Query("batch_size"); batchSize != "" {
+		value, err := strconv.Atoi(batchSize)
+		if err != nil || value <= 0 || value > usecase.MaxImportBatchSize {
+			return nil, fmt.Errorf("batch_size must be between 1 and %d", usecase.MaxImportBatchSize)
+		}
+		opts.BatchSize = value
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"go-test-grpc-http/internal/api/http/middlewares"
+	"go-test-grpc-http/internal/api/http/presenter"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// serveTest выполняет запрос к handler так же, как роутер: с обработкой ошибок и, если callerID
+// не nil, от имени аутентифицированного пользователя
+func serveTest(handler gin.HandlerFunc, route string, target string, callerID *entity.UserID) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(middlewares.NewErrorMiddleware(zap.NewNop()))
+	engine.GET(route, func(c *gin.Context) {
+		if callerID != nil {
+			c.Set("user-id", callerID)
+		}
+		handler(c)
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return w
+}
+
+func Test_adminHandlers_ExportJobNotFound(t *testing.T) {
+	exportJobs := usecase.NewExportJobs(nil, t.TempDir())
+	h := NewAdminHandlers(nil, exportJobs, presenter.NewUserPresenter(), presenter.NewImportPresenter(), presenter.NewExportPresenter())
+
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		route   string
+		target  string
+	}{
+		{
+			name:    "job status",
+			handler: h.GetExportJobHandler,
+			route:   "/jobs/:id",
+			target:  "/jobs/unknown",
+		},
+		{
+			name:    "job file",
+			handler: h.DownloadExportJobHandler,
+			route:   "/jobs/:id/file",
+			target:  "/jobs/unknown/file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveTest(tt.handler, tt.route, tt.target, nil)
+			if w.Code != http.StatusNotFound {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, http.StatusNotFound, w.Body)
+			}
+		})
+	}
+}
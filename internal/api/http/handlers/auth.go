@@ -1,12 +1,14 @@
 package handlers
 
 import (
-	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"go-test-grpc-http/internal/api/http/presenter"
-	_ "go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/domain"
 	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/metrics"
 	"go-test-grpc-http/internal/usecase"
 	"net/http"
 
@@ -14,14 +16,16 @@ import (
 )
 
 type authHandlers struct {
-	interactor usecase.UserInteractor
-	presenter  presenter.TokenPresenter
+	interactor    usecase.UserInteractor
+	presenter     presenter.TokenPresenter
+	userPresenter presenter.UserPresenter
 }
 
-func NewAuthHandlers(interactor usecase.UserInteractor, presenter presenter.TokenPresenter) *authHandlers {
+func NewAuthHandlers(interactor usecase.UserInteractor, presenter presenter.TokenPresenter, userPresenter presenter.UserPresenter) *authHandlers {
 	return &authHandlers{
-		interactor: interactor,
-		presenter:  presenter,
+		interactor:    interactor,
+		presenter:     presenter,
+		userPresenter: userPresenter,
 	}
 }
 
@@ -31,48 +35,33 @@ func NewAuthHandlers(interactor usecase.UserInteractor, presenter presenter.Toke
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param request body entity.UserCreate true "Данные пользователя для регистрации"
+// @Param request body view.UserCreateRequest true "Данные пользователя для регистрации"
 // @Success 201 {object} view.TokenView "Токен авторизации"
-// @Failure 400 "Некорректный запрос"
-// @Failure 422 "Ошибка при обработке данных"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 409 {object} view.ProblemView "Email уже занят"
+// @Failure 413 {object} view.ProblemView "Слишком большое тело запроса"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /auth/signup [post]
 func (a *authHandlers) SignUp(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
-	data, err := c.GetRawData()
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't sign up user: %v", err))
-		return
-	}
-
-	var user *entity.UserCreate
-	err = json.Unmarshal(data, &user)
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't sign up user: %v", err))
-		return
-	}
-
-	userId, err := a.interactor.GetIdByEmail(ctx, user.Email)
-	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't get user from db: %v", err))
-		return
-	}
-
-	if userId != nil {
-		c.AbortWithStatus(http.StatusConflict)
+	var request view.UserCreateRequest
+	if err := bindJSON(c, &request); err != nil {
+		abortWithError(c, fmt.Errorf("can't sign up user: %w", err))
 		return
 	}
 
-	userId, err = a.interactor.Create(ctx, user)
+	userId, err := a.interactor.Create(ctx, a.userPresenter.ToUserCreate(&request))
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign up user: %v", err))
+		abortWithError(c, fmt.Errorf("can't sign up user: %w", err))
 		return
 	}
+	metrics.SignUps.Inc()
 
 	token, err := a.presenter.ToTokenView(entity.GenerateToken(userId))
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign up user: %v", err))
+		abortWithError(c, fmt.Errorf("can't sign up user: %w", err))
 		return
 	}
 
@@ -85,43 +74,42 @@ func (a *authHandlers) SignUp(c *gin.Context) {
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param request body entity.UserSignIn true "Данные пользователя для входа"
+// @Param request body view.SignInRequest true "Данные пользователя для входа"
 // @Success 200 {object} view.TokenView "Токен авторизации"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Ошибка авторизации"
-// @Failure 422 "Ошибка при обработке данных"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Ошибка авторизации"
+// @Failure 403 {object} view.ProblemView "Пользователь заблокирован"
+// @Failure 413 {object} view.ProblemView "Слишком большое тело запроса"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /auth/signin [post]
 func (a *authHandlers) SignIn(c *gin.Context) {
-	ctx := context.Background()
-
-	data, err := c.GetRawData()
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't sign up user: %v", err))
-		return
-	}
+	ctx := c.Request.Context()
 
-	var user *entity.UserSignIn
-	err = json.Unmarshal(data, &user)
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't sign up user: %v", err))
+	var request view.SignInRequest
+	if err := bindJSON(c, &request); err != nil {
+		abortWithError(c, fmt.Errorf("can't sign in user: %w", err))
 		return
 	}
 
-	userID, err := a.interactor.GetIdByEmail(ctx, user.Email)
+	userID, err := a.interactor.SignIn(ctx, request.Email)
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't get user from interactor: %v", err))
-		return
-	}
-
-	if userID == nil {
-		c.AbortWithStatus(http.StatusConflict)
+		if errors.Is(err, domain.ErrNotFound) {
+			metrics.FailedLogins.Inc()
+			problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
+			return
+		}
+		if errors.Is(err, entity.ErrUserDisabled) {
+			metrics.FailedLogins.Inc()
+		}
+		abortWithError(c, fmt.Errorf("can't get user from interactor: %w", err))
 		return
 	}
+	metrics.SignIns.Inc()
 
 	token, err := a.presenter.ToTokenView(entity.GenerateToken(userID))
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't sign in user: %v", err))
+		abortWithError(c, fmt.Errorf("can't sign in user: %w", err))
 		return
 	}
 
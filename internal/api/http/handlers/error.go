@@ -1,11 +1,27 @@
 package handlers
 
 import (
+	"fmt"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/domain"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 func NotImplementedHandler(c *gin.Context) {
-	c.AbortWithStatus(http.StatusMethodNotAllowed)
+	problem.AbortWithStatus(c, http.StatusMethodNotAllowed, nil)
+}
+
+// abortWithError прерывает запрос со статусом, соответствующим виду ошибки предметной области
+func abortWithError(c *gin.Context, err error) {
+	problem.Abort(c, err)
+}
+
+// invalidParam создает ошибку некорректного параметра запроса с нарушением для поля name
+func invalidParam(name string, err error) error {
+	return domain.InvalidFields(fmt.Sprintf("invalid %s", name), domain.Violation{
+		Field:       name,
+		Description: err.Error(),
+	})
 }
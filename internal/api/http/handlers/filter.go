@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseUserFilter разбирает фильтр списка пользователей из параметров запроса
+func parseUserFilter(c *gin.Context) (*entity.UserFilter, error) {
+	filter := &entity.UserFilter{
+		Email: c.Query("email"),
+		Name:  c.Query("name"),
+	}
+
+	switch role := entity.Role(c.Query("role")); role {
+	case "", entity.RoleUser, entity.RoleAdmin:
+		filter.Role = role
+	default:
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+
+	for param, dst := range map[string]**int{
+		"min_age": &filter.MinAge,
+		"max_age": &filter.MaxAge,
+	} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		age, err := strconv.Atoi(value)
+		if err != nil || age < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative number", param)
+		}
+		*dst = &age
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"fmt"
+	"go-test-grpc-http/internal/api/http/presenter"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeUserDataArchive отдает архив данных пользователя как вложение
+func writeUserDataArchive(c *gin.Context, interactor usecase.UserInteractor, presenter presenter.UserPresenter, id *entity.UserID) {
+	archive, err := interactor.ExportData(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't export user data: %w", err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%s.json"`, id))
+	c.JSON(http.StatusOK, presenter.ToUserDataArchiveView(archive))
+}
+
+// eraseUser удаляет персональные данные пользователя
+func eraseUser(c *gin.Context, interactor usecase.UserInteractor, id *entity.UserID) {
+	err := interactor.Erase(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't erase user: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
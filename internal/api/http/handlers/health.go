@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type healthHandlers struct {
+	registry *health.Registry
+}
+
+func NewHealthHandlers(registry *health.Registry) *healthHandlers {
+	return &healthHandlers{
+		registry: registry,
+	}
+}
+
+// Healthz проверка живости: процесс запущен и обрабатывает запросы
+func (h *healthHandlers) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, view.HealthView{Status: health.StatusServing})
+}
+
+// Readyz проверка готовности: все подсистемы готовы принимать трафик.
+// Возвращает 503, если хотя бы одна проверка не прошла или приложение останавливается.
+func (h *healthHandlers) Readyz(c *gin.Context) {
+	report := h.registry.Ready(c.Request.Context())
+
+	response := view.HealthView{
+		Status: report.Status,
+		Checks: make(map[string]view.HealthCheckView, len(report.Checks)),
+	}
+	for name, result := range report.Checks {
+		response.Checks[name] = view.HealthCheckView{
+			Status: result.Status,
+			Error:  result.Error,
+		}
+	}
+
+	status := http.StatusOK
+	if report.Status != health.StatusServing {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
+}
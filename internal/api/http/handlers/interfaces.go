@@ -12,9 +12,27 @@ type UserHandlers interface {
 	GetByEmailHandler(c *gin.Context)
 	UpdateHandler(c *gin.Context)
 	DeleteHandler(c *gin.Context)
+	GetHistoryHandler(c *gin.Context)
+	ExportMeHandler(c *gin.Context)
+	EraseMeHandler(c *gin.Context)
 }
 
 type AuthHandlers interface {
 	SignUp(c *gin.Context)
 	SignIn(c *gin.Context)
 }
+
+type AdminHandlers interface {
+	ImportUsersHandler(c *gin.Context)
+	ExportUsersHandler(c *gin.Context)
+	StartExportJobHandler(c *gin.Context)
+	GetExportJobHandler(c *gin.Context)
+	DownloadExportJobHandler(c *gin.Context)
+	ExportUserDataHandler(c *gin.Context)
+	EraseUserHandler(c *gin.Context)
+}
+
+type HealthHandlers interface {
+	Healthz(c *gin.Context)
+	Readyz(c *gin.Context)
+}
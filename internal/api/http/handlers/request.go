@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/usecase"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxRequestBodySize максимальный размер тела JSON-запроса
+const MaxRequestBodySize = 1 << 20
+
+var requestValidator = newRequestValidator()
+
+func newRequestValidator() *validator.Validate {
+	v := validator.New()
+	// В нарушениях используются имена полей из JSON
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	_ = v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return usecase.IsValidPhone(fl.Field().String())
+	})
+
+	return v
+}
+
+// bindJSON строго разбирает тело запроса в dst и проверяет его правилами из тегов validate.
+//
+//	Неизвестные поля, данные после JSON-объекта и тело больше MaxRequestBodySize отклоняются.
+func bindJSON(c *gin.Context, dst any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(c.Writer, c.Request.Body, MaxRequestBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return domain.Invalid("request body must contain a single JSON object")
+	}
+
+	if err := requestValidator.Struct(dst); err != nil {
+		var validationErrs validator.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			return fmt.Errorf("can't validate request: %w", err)
+		}
+		return invalidRequest(validationErrs)
+	}
+
+	return nil
+}
+
+func decodeError(err error) error {
+	var (
+		typeErr     *json.UnmarshalTypeError
+		maxBytesErr *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return problem.WithStatus(http.StatusRequestEntityTooLarge,
+			domain.Invalid(fmt.Sprintf("request body must be at most %d bytes", maxBytesErr.Limit)))
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return domain.InvalidFields("invalid request body", domain.Violation{
+			Field:       typeErr.Field,
+			Description: fmt.Sprintf("must be %s", typeErr.Type),
+		})
+	case errors.Is(err, io.EOF):
+		return domain.Invalid("request body is empty")
+	}
+
+	// Ошибки encoding/json о неизвестном поле и синтаксисе не имеют отдельных типов
+	return fmt.Errorf("%w: %s", domain.Invalid("invalid request body"), strings.TrimPrefix(err.Error(), "json: "))
+}
+
+func invalidRequest(errs validator.ValidationErrors) error {
+	violations := make([]domain.Violation, 0, len(errs))
+	for _, fieldErr := range errs {
+		violations = append(violations, domain.Violation{
+			Field:       fieldErr.Field(),
+			Description: describeRule(fieldErr),
+		})
+	}
+
+	return domain.InvalidFields("invalid request body", violations...)
+}
+
+// describeRule описывает нарушенное правило так же, как usecase.ValidateUserCreate
+func describeRule(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "is invalid"
+	case "phone":
+		return "must be in E.164 format"
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters", fieldErr.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fieldErr.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fieldErr.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fieldErr.Param())
+	default:
+		return fmt.Sprintf("must satisfy %s", fieldErr.Tag())
+	}
+}
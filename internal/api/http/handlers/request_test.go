@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Test_bindJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		want           *view.UserCreateRequest
+		wantStatus     int
+		wantViolations []string
+	}{
+		{
+			name: "success",
+			body: `{"firstName":"Иван","lastName":"Иванов","age":30,"email":"ivan@example.com","phone":"+79991234567","password":"qwerty"}`,
+			want: &view.UserCreateRequest{
+				FirstName: "Иван",
+				LastName:  "Иванов",
+				Age:       30,
+				Email:     "ivan@example.com",
+				Phone:     "+79991234567",
+				Password:  "qwerty",
+			},
+		},
+		{
+			name:       "unknown field",
+			body:       `{"firstName":"Иван","role":"admin"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "trailing data",
+			body:       `{"firstName":"Иван"} {}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "wrong type",
+			body:           `{"age":"thirty"}`,
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantViolations: []string{"age must be int"},
+		},
+		{
+			name:       "too large",
+			body:       `{"firstName":"` + strings.Repeat("a", MaxRequestBodySize) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "violations",
+			body:       `{"lastName":"Иванов","age":-1,"email":"ivan","phone":"123","password":"qwerty"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantViolations: []string{
+				"firstName is required",
+				"age must be greater than or equal to 0",
+				"email is invalid",
+				"phone must be in E.164 format",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			var got view.UserCreateRequest
+			err := bindJSON(c, &got)
+			if tt.want != nil {
+				if err != nil {
+					t.Fatalf("bindJSON() error = %v", err)
+				}
+				if !reflect.DeepEqual(&got, tt.want) {
+					t.Errorf("bindJSON() = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrInvalid) {
+				t.Fatalf("bindJSON() error = %v, want %v", err, domain.ErrInvalid)
+			}
+			if status := problem.Status(err); status != tt.wantStatus {
+				t.Errorf("bindJSON() status = %d, want %d", status, tt.wantStatus)
+			}
+			if tt.wantViolations != nil {
+				var violations []string
+				for _, violation := range domain.ViolationsOf(err) {
+					violations = append(violations, violation.String())
+				}
+				if !reflect.DeepEqual(violations, tt.wantViolations) {
+					t.Errorf("bindJSON() violations = %v, want %v", violations, tt.wantViolations)
+				}
+			}
+		})
+	}
+}
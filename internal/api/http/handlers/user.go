@@ -1,20 +1,24 @@
 package handlers
 
 import (
-	"context"
-	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"go-test-grpc-http/internal/api/http/presenter"
-	_ "go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/domain"
 	"go-test-grpc-http/internal/entity"
 	"go-test-grpc-http/internal/usecase"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// errHistoryForbidden возвращается, если историю чужого пользователя запрашивает не администратор
+var errHistoryForbidden = domain.Forbidden("only the user or an admin can view the history")
+
 type userHandlers struct {
 	interactor usecase.UserInteractor
 	presenter  presenter.UserPresenter
@@ -35,32 +39,27 @@ func NewUserHandlers(interactor usecase.UserInteractor, presenter presenter.User
 // @Produce plain
 // @Security JwtAuth
 // @Success 200 {object} view.UserView "Данные пользователя"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/me [get]
 func (h *userHandlers) GetMeHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	id, exists := c.Get("user-id")
 	if !exists {
-		c.AbortWithStatus(http.StatusUnauthorized)
+		problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
 		return
 	}
 
 	user, err := h.interactor.GetById(ctx, id.(*entity.UserID))
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't get user: %w", err))
-		return
-	}
-
-	if user == nil {
-		c.AbortWithStatus(http.StatusNotFound)
+		abortWithError(c, fmt.Errorf("can't get user: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, h.presenter.ToUserView(user))
+	c.JSON(http.StatusOK, h.presenter.ToUserView(user, entity.UserAudienceOf(user, user)))
 }
 
 // UpdateMeHandler godoc
@@ -69,49 +68,39 @@ func (h *userHandlers) GetMeHandler(c *gin.Context) {
 // @Tags Users
 // @Accept json
 // @Produce json
-// @Param request body entity.UserCreate true "Данные пользователя для обновления"
+// @Param request body view.UserCreateRequest true "Данные пользователя для обновления"
 // @Security JwtAuth
 // @Success 200 {object} view.UserView "Обновленные данные пользователя"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 422 "Ошибка при обработке данных"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 409 {object} view.ProblemView "Email уже занят"
+// @Failure 413 {object} view.ProblemView "Слишком большое тело запроса"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/me [put]
 func (h *userHandlers) UpdateMeHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	id, exists := c.Get("user-id")
 	if !exists {
-		c.AbortWithStatus(http.StatusUnauthorized)
-		return
-	}
-
-	body, err := c.GetRawData()
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+		problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
 		return
 	}
 
-	var user entity.UserCreate
-	err = json.Unmarshal(body, &user)
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+	var request view.UserCreateRequest
+	if err := bindJSON(c, &request); err != nil {
+		abortWithError(c, fmt.Errorf("can't update user: %w", err))
 		return
 	}
 
-	dbUser, err := h.interactor.Update(ctx, id.(*entity.UserID), &user)
+	dbUser, err := h.interactor.Update(ctx, id.(*entity.UserID), h.presenter.ToUserCreate(&request))
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't update user: %w", err))
-		return
-	}
-
-	if dbUser == nil {
-		c.AbortWithStatus(http.StatusNotFound)
+		abortWithError(c, fmt.Errorf("can't update user: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, h.presenter.ToUserView(dbUser))
+	c.JSON(http.StatusOK, h.presenter.ToUserView(dbUser, entity.UserAudienceOf(dbUser, dbUser)))
 }
 
 // DeleteMeHandler godoc
@@ -122,27 +111,23 @@ func (h *userHandlers) UpdateMeHandler(c *gin.Context) {
 // @Produce plain
 // @Security JwtAuth
 // @Success 204 "Пользователь успешно удален"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/me [delete]
 func (h *userHandlers) DeleteMeHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	id, exists := c.Get("user-id")
 	if !exists {
-		c.AbortWithStatus(http.StatusUnauthorized)
+		problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
 		return
 	}
 
 	err := h.interactor.Delete(ctx, id.(*entity.UserID))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			c.AbortWithStatus(http.StatusNotFound)
-			return
-		}
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't delete user: %w", err))
+		abortWithError(c, fmt.Errorf("can't delete user: %w", err))
 		return
 	}
 
@@ -158,32 +143,33 @@ func (h *userHandlers) DeleteMeHandler(c *gin.Context) {
 // @Param id path string true "Уникальный идентификатор пользователя (UUID)"
 // @Security JwtAuth
 // @Success 200 {object} view.UserView "Данные пользователя"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/id/{id} [get]
 func (h *userHandlers) GetByIdHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("invalid id: %w", err))
+		abortWithError(c, invalidParam("id", err))
 		return
 	}
 
 	user, err := h.interactor.GetById(ctx, &entity.UserID{Id: id})
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't get user: %w", err))
+		abortWithError(c, fmt.Errorf("can't get user: %w", err))
 		return
 	}
 
-	if user == nil {
-		c.AbortWithStatus(http.StatusNotFound)
+	audience, err := h.audience(c, user)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't get user: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, h.presenter.ToUserView(user))
+	c.JSON(http.StatusOK, h.presenter.ToUserView(user, audience))
 }
 
 // GetByEmailHandler godoc
@@ -195,27 +181,28 @@ func (h *userHandlers) GetByIdHandler(c *gin.Context) {
 // @Param email path string true "Email пользователя"
 // @Security JwtAuth
 // @Success 200 {object} view.UserView "Данные пользователя"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/email/{email} [get]
 func (h *userHandlers) GetByEmailHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	email := c.Param("email")
 	user, err := h.interactor.GetByEmail(ctx, email)
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't get user: %w", err))
+		abortWithError(c, fmt.Errorf("can't get user: %w", err))
 		return
 	}
 
-	if user == nil {
-		c.AbortWithStatus(http.StatusNotFound)
+	audience, err := h.audience(c, user)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't get user: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, h.presenter.ToUserView(user))
+	c.JSON(http.StatusOK, h.presenter.ToUserView(user, audience))
 }
 
 // UpdateHandler godoc
@@ -225,44 +212,45 @@ func (h *userHandlers) GetByEmailHandler(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Уникальный идентификатор пользователя (UUID)"
-// @Param request body entity.UserCreate true "Данные пользователя для обновления"
+// @Param request body view.UserCreateRequest true "Данные пользователя для обновления"
 // @Security JwtAuth
 // @Success 200 {object} view.UserView "Обновленные данные пользователя"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 422 "Ошибка при обработке данных"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 409 {object} view.ProblemView "Email уже занят"
+// @Failure 413 {object} view.ProblemView "Слишком большое тело запроса"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/id/{id} [put]
 func (h *userHandlers) UpdateHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		abortWithError(c, invalidParam("id", err))
 		return
 	}
 
-	body, err := c.GetRawData()
-	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't read body: %w", err))
+	var request view.UserCreateRequest
+	if err := bindJSON(c, &request); err != nil {
+		abortWithError(c, fmt.Errorf("can't update user: %w", err))
 		return
 	}
 
-	var user entity.UserCreate
-	err = json.Unmarshal(body, &user)
+	dbUser, err := h.interactor.Update(ctx, &entity.UserID{Id: id}, h.presenter.ToUserCreate(&request))
 	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, fmt.Errorf("can't unmarshal body: %w", err))
+		abortWithError(c, fmt.Errorf("can't update user: %w", err))
 		return
 	}
 
-	dbUser, err := h.interactor.Update(ctx, &entity.UserID{Id: id}, &user)
+	audience, err := h.audience(c, dbUser)
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't update user: %w", err))
+		abortWithError(c, fmt.Errorf("can't get user: %w", err))
 		return
 	}
 
-	c.JSON(http.StatusOK, h.presenter.ToUserView(dbUser))
+	c.JSON(http.StatusOK, h.presenter.ToUserView(dbUser, audience))
 }
 
 // DeleteHandler godoc
@@ -274,25 +262,177 @@ func (h *userHandlers) UpdateHandler(c *gin.Context) {
 // @Param id path string true "Уникальный идентификатор пользователя (UUID)"
 // @Security JwtAuth
 // @Success 204 "Пользователь успешно удален"
-// @Failure 400 "Некорректный запрос"
-// @Failure 401 "Неавторизованный запрос"
-// @Failure 404 "Пользователь не найден"
-// @Failure 500 "Внутренняя ошибка сервера"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
 // @Router /users/id/{id} [delete]
 func (h *userHandlers) DeleteHandler(c *gin.Context) {
-	ctx := context.Background()
+	ctx := c.Request.Context()
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithError(http.StatusUnprocessableEntity, err)
+		abortWithError(c, invalidParam("id", err))
 		return
 	}
 
 	err = h.interactor.Delete(ctx, &entity.UserID{Id: id})
 	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't delete user: %w", err))
+		abortWithError(c, fmt.Errorf("can't delete user: %w", err))
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// GetHistoryHandler godoc
+// @Summary История изменений пользователя
+// @Description Постраничное получение истории создания, изменения и удаления пользователя, от новых записей к старым.
+// @Description Для следующей страницы передайте next_cursor из предыдущего ответа.
+// @Description Доступно самому пользователю и администраторам.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "Уникальный идентификатор пользователя (UUID)"
+// @Param cursor query int false "Курсор страницы"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Security JwtAuth
+// @Success 200 {object} view.UserHistoryView "История пользователя"
+// @Failure 400 {object} view.ProblemView "Некорректный запрос"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 403 {object} view.ProblemView "Недостаточно прав"
+// @Failure 422 {object} view.ProblemView "Ошибка при обработке данных"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /users/id/{id}/history [get]
+func (h *userHandlers) GetHistoryHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortWithError(c, invalidParam("id", err))
+		return
+	}
+
+	var cursor int64
+	if value := c.Query("cursor"); value != "" {
+		cursor, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || cursor < 0 {
+			problem.AbortWithStatus(c, http.StatusBadRequest, invalidParam("cursor", errors.New("must be a non-negative number")))
+			return
+		}
+	}
+
+	var limit int
+	if value := c.Query("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			problem.AbortWithStatus(c, http.StatusBadRequest, invalidParam("limit", errors.New("must be a non-negative number")))
+			return
+		}
+	}
+
+	userID := &entity.UserID{Id: id}
+	audience, err := h.historyAudience(c, userID)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't get user history: %w", err))
+		return
+	}
+
+	page, err := h.interactor.GetHistory(ctx, userID, cursor, limit)
+	if err != nil {
+		abortWithError(c, fmt.Errorf("can't get user history: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.presenter.ToUserHistoryView(page, audience))
+}
+
+// ExportMeHandler godoc
+// @Summary Выгрузка всех данных пользователя по JWT токену
+// @Description Возвращает JSON-архив со всеми данными, хранимыми о пользователе: профиль, история изменений
+// @Description и действия пользователя. Пароль в архив не включается. Список sessions всегда пуст: токены доступа (JWT)
+// @Description не хранят состояния, и записи о сеансах не ведутся.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security JwtAuth
+// @Success 200 {object} view.UserDataArchiveView "Архив данных пользователя"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /users/me/export [get]
+func (h *userHandlers) ExportMeHandler(c *gin.Context) {
+	id, exists := c.Get("user-id")
+	if !exists {
+		problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
+		return
+	}
+
+	writeUserDataArchive(c, h.interactor, h.presenter, id.(*entity.UserID))
+}
+
+// EraseMeHandler godoc
+// @Summary Удаление персональных данных пользователя по JWT токену
+// @Description Обезличивает пользователя: персональные данные в профиле и истории изменений затираются,
+// @Description записи и связи между ними сохраняются. Войти под пользователем после этого нельзя.
+// @Tags Users
+// @Accept json
+// @Produce plain
+// @Security JwtAuth
+// @Success 204 "Персональные данные удалены"
+// @Failure 401 {object} view.ProblemView "Неавторизованный запрос"
+// @Failure 404 {object} view.ProblemView "Пользователь не найден"
+// @Failure 500 {object} view.ProblemView "Внутренняя ошибка сервера"
+// @Router /users/me/erase [post]
+func (h *userHandlers) EraseMeHandler(c *gin.Context) {
+	id, exists := c.Get("user-id")
+	if !exists {
+		problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
+		return
+	}
+
+	eraseUser(c, h.interactor, id.(*entity.UserID))
+}
+
+// audience определяет по роли вызывающего, какие поля user ему доступны
+func (h *userHandlers) audience(c *gin.Context, user *entity.User) (entity.UserAudience, error) {
+	id, exists := c.Get("user-id")
+	if !exists {
+		return entity.UserAudienceOther, nil
+	}
+
+	viewer, err := h.interactor.GetById(c.Request.Context(), id.(*entity.UserID))
+	if errors.Is(err, domain.ErrNotFound) {
+		return entity.UserAudienceOther, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't get caller: %w", err)
+	}
+
+	return entity.UserAudienceOf(viewer, user), nil
+}
+
+// historyAudience определяет, какие поля снимков истории пользователя id доступны вызывающему.
+// Историю видит только сам пользователь и администраторы. Пользователь может быть уже удален,
+// поэтому загружается только вызывающий.
+func (h *userHandlers) historyAudience(c *gin.Context, id *entity.UserID) (entity.UserAudience, error) {
+	callerID, exists := c.Get("user-id")
+	if !exists {
+		return "", problem.WithStatus(http.StatusUnauthorized, nil)
+	}
+
+	caller, err := h.interactor.GetById(c.Request.Context(), callerID.(*entity.UserID))
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", problem.WithStatus(http.StatusUnauthorized, nil)
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't get caller: %w", err)
+	}
+
+	audience := entity.UserAudienceOf(caller, &entity.User{ID: id})
+	if audience == entity.UserAudienceOther {
+		return "", errHistoryForbidden
+	}
+
+	return audience, nil
+}
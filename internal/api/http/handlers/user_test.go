@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"go-test-grpc-http/internal/api/http/presenter"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func Test_userHandlers_GetHistoryHandler(t *testing.T) {
+	owner := &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}
+	other := &entity.UserID{Id: uuid.MustParse("c4a5e6b0-7d1f-4f5e-9a43-0c2d5b6e8f11")}
+	admin := &entity.UserID{Id: uuid.MustParse("0b8e3f52-1c6d-4a7e-8f90-2d3c4b5a6e7f")}
+
+	type mockBehavior func(r *usecase.MockUserInteractor)
+
+	tests := []struct {
+		name         string
+		caller       *entity.UserID
+		mockBehavior mockBehavior
+		wantStatus   int
+	}{
+		{
+			name:   "own history",
+			caller: owner,
+			mockBehavior: func(r *usecase.MockUserInteractor) {
+				r.EXPECT().GetById(gomock.Any(), owner).Return(&entity.User{ID: owner, Role: entity.RoleUser}, nil)
+				r.EXPECT().GetHistory(gomock.Any(), owner, int64(0), 0).Return(&entity.UserHistoryPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "admin",
+			caller: admin,
+			mockBehavior: func(r *usecase.MockUserInteractor) {
+				r.EXPECT().GetById(gomock.Any(), admin).Return(&entity.User{ID: admin, Role: entity.RoleAdmin}, nil)
+				r.EXPECT().GetHistory(gomock.Any(), owner, int64(0), 0).Return(&entity.UserHistoryPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "other user",
+			caller: other,
+			mockBehavior: func(r *usecase.MockUserInteractor) {
+				r.EXPECT().GetById(gomock.Any(), other).Return(&entity.User{ID: other, Role: entity.RoleUser}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:         "anonymous",
+			mockBehavior: func(r *usecase.MockUserInteractor) {},
+			wantStatus:   http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			interactor := usecase.NewMockUserInteractor(c)
+			tt.mockBehavior(interactor)
+			h := NewUserHandlers(interactor, presenter.NewUserPresenter())
+
+			w := serveTest(h.GetHistoryHandler, "/users/id/:id/history", "/users/id/"+owner.String()+"/history", tt.caller)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
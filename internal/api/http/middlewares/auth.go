@@ -1,9 +1,13 @@
 package middlewares
 
 import (
+	"errors"
 	"fmt"
 	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/domain"
 	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
 	"net/http"
 	"strings"
 
@@ -11,12 +15,12 @@ import (
 )
 
 // The NewAuthMiddleware function is a middleware that handles authentication by checking for a valid
-// API key and JWT token in the Authorization header.
-func NewAuthMiddleware() gin.HandlerFunc {
+// API key and JWT token in the Authorization header. Tokens of deleted or disabled users are rejected.
+func NewAuthMiddleware(interactor usecase.UserInteractor) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		cfg, err := config.GetAppConfig()
 		if err != nil {
-			c.AbortWithError(http.StatusInternalServerError, err)
+			problem.AbortWithStatus(c, http.StatusInternalServerError, err)
 			return
 		}
 
@@ -27,22 +31,39 @@ func NewAuthMiddleware() gin.HandlerFunc {
 
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatus(http.StatusUnauthorized)
+			problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if len(tokenString) == 0 {
-			c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("invalid token format"))
+			problem.AbortWithStatus(c, http.StatusUnauthorized, fmt.Errorf("invalid token format"))
+			return
 		}
 
 		id, err := entity.ParseToken(tokenString)
 		if err != nil {
-			c.AbortWithError(http.StatusUnauthorized, fmt.Errorf("invalid token: %v", err))
+			problem.AbortWithStatus(c, http.StatusUnauthorized, fmt.Errorf("invalid token: %v", err))
+			return
+		}
+
+		// Токен действует до истечения срока, поэтому блокировка проверяется при каждом запросе
+		user, err := interactor.GetById(c.Request.Context(), id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
+				return
+			}
+			problem.Abort(c, fmt.Errorf("can't get user: %w", err))
+			return
+		}
+		if user.DisabledAt != nil {
+			problem.Abort(c, entity.ErrUserDisabled)
 			return
 		}
 
 		c.Set("user-id", id)
+		c.Request = c.Request.WithContext(entity.ContextWithActor(c.Request.Context(), id))
 		c.Next()
 	}
 }
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation сведения об устаревшей версии API
+type Deprecation struct {
+	// At дата, с которой версия считается устаревшей
+	At time.Time
+	// Sunset дата, после которой версия перестанет обслуживаться
+	Sunset time.Time
+	// Successor базовый путь версии, на которую следует перейти
+	Successor string
+}
+
+// The NewDeprecationMiddleware function is a middleware that marks responses of a deprecated API version
+// with the Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers pointing to the successor version.
+func NewDeprecationMiddleware(deprecation Deprecation) gin.HandlerFunc {
+	deprecatedAt := fmt.Sprintf("@%d", deprecation.At.Unix())
+	sunset := deprecation.Sunset.UTC().Format(http.TimeFormat)
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, deprecation.Successor)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecatedAt)
+		c.Header("Sunset", sunset)
+		if deprecation.Successor != "" {
+			c.Header("Link", link)
+		}
+		c.Next()
+	}
+}
@@ -1,10 +1,16 @@
 package middlewares
 
 import (
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/tracing"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
+// The NewErrorMiddleware function is a middleware that logs the errors collected while handling the request
+// and, if the response has not been written yet, renders the last one as application/problem+json.
 func NewErrorMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -13,6 +19,30 @@ func NewErrorMiddleware(logger *zap.Logger) gin.HandlerFunc {
 			return
 		}
 
-		logger.Error("get errors while handle request", zap.Reflect("errors", c.Errors.Errors()))
+		err := c.Errors.Last().Err
+		status := problem.Status(err)
+		requestID := c.GetString("request-id")
+
+		fields := []zap.Field{
+			zap.String("request_id", requestID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", status),
+			zap.Strings("errors", c.Errors.Errors()),
+		}
+		fields = append(fields, tracing.LogFields(c.Request.Context())...)
+		if status >= http.StatusInternalServerError {
+			logger.Error("get errors while handle request", fields...)
+		} else {
+			logger.Warn("get errors while handle request", fields...)
+		}
+
+		// Ответ уже начал передаваться (например, потоковая выгрузка), статус изменить нельзя
+		if c.Writer.Written() {
+			return
+		}
+
+		c.Header("Content-Type", problem.ContentType)
+		c.JSON(status, problem.New(c, err, requestID))
 	}
 }
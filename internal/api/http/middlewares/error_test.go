@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestNewErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		want    *view.ProblemView
+	}{
+		{
+			name: "domain error",
+			handler: func(c *gin.Context) {
+				problem.Abort(c, fmt.Errorf("can't get user: %w", domain.NotFound("user not found")))
+			},
+			want: &view.ProblemView{
+				Type:      "urn:problem-type:not-found",
+				Title:     "Not Found",
+				Status:    http.StatusNotFound,
+				Detail:    "user not found",
+				Instance:  "/test",
+				RequestID: "request-1",
+			},
+		},
+		{
+			name: "explicit status with violations",
+			handler: func(c *gin.Context) {
+				problem.AbortWithStatus(c, http.StatusBadRequest, domain.InvalidFields("invalid limit", domain.Violation{
+					Field:       "limit",
+					Description: "must be a non-negative number",
+				}))
+			},
+			want: &view.ProblemView{
+				Type:      "urn:problem-type:invalid",
+				Title:     "Bad Request",
+				Status:    http.StatusBadRequest,
+				Detail:    "invalid limit",
+				Instance:  "/test",
+				RequestID: "request-1",
+				Violations: []*view.ViolationView{
+					{Field: "limit", Message: "must be a non-negative number"},
+				},
+			},
+		},
+		{
+			name: "internal error is hidden",
+			handler: func(c *gin.Context) {
+				problem.Abort(c, fmt.Errorf("can't get user: %w", errors.New("connection refused")))
+			},
+			want: &view.ProblemView{
+				Type:      "about:blank",
+				Title:     "Internal Server Error",
+				Status:    http.StatusInternalServerError,
+				Detail:    "Что-то пошло не так. Попробуйте еще раз.",
+				Instance:  "/test",
+				RequestID: "request-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(NewRequestIDMiddleware(), NewErrorMiddleware(zap.NewNop()))
+			r.GET("/test", tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set(RequestIDHeader, "request-1")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want.Status {
+				t.Errorf("status = %d, want %d", w.Code, tt.want.Status)
+			}
+			if got := w.Header().Get("Content-Type"); got != problem.ContentType {
+				t.Errorf("Content-Type = %q, want %q", got, problem.ContentType)
+			}
+
+			var got view.ProblemView
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("can't unmarshal body: %v", err)
+			}
+			if !reflect.DeepEqual(&got, tt.want) {
+				t.Errorf("body = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
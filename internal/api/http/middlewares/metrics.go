@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"go-test-grpc-http/internal/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Метка маршрута для запросов, не совпавших ни с одним шаблоном
+const unmatchedRoute = "unmatched"
+
+// The NewMetricsMiddleware function is a middleware that counts requests and observes their latency
+// labeled by the route template (not the raw path) and the response status.
+func NewMetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"errors"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/metrics"
+	"go-test-grpc-http/internal/ratelimit"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// The NewRateLimitMiddleware function is a middleware that rejects requests over the client's rate limit
+// with 429 Too Many Requests. Clients are told apart by the user in the JWT token or by the client IP.
+func NewRateLimitMiddleware(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limiter.Allow(ratelimit.ClientKey(c.GetHeader("Authorization"), c.ClientIP())) {
+			metrics.RateLimited.WithLabelValues("http").Inc()
+			problem.AbortWithStatus(c, http.StatusTooManyRequests, errRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
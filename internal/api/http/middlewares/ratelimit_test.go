@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"go-test-grpc-http/internal/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestNewRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := ratelimit.NewLimiter(func() (float64, int) { return 0.001, 1 })
+	engine := gin.New()
+	engine.Use(NewErrorMiddleware(zap.NewNop()), NewRateLimitMiddleware(limiter))
+	engine.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	request := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       int
+	}{
+		{name: "first request", remoteAddr: "192.0.2.1:1000", want: http.StatusNoContent},
+		{name: "same client over limit", remoteAddr: "192.0.2.1:2000", want: http.StatusTooManyRequests},
+		{name: "other client", remoteAddr: "192.0.2.2:1000", want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := request(tt.remoteAddr); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// The NewRequestIDMiddleware function is a middleware that takes the request ID from the X-Request-ID
+// header or generates a new one, stores it in the context and returns it in the response header.
+func NewRequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = uuid.NewString()
+		}
+
+		c.Set("request-id", id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The NewRoleMiddleware function is a middleware that allows the request only if the user
+// authenticated by NewAuthMiddleware has one of the given roles and is not disabled.
+func NewRoleMiddleware(interactor usecase.UserInteractor, roles ...entity.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, exists := c.Get("user-id")
+		if !exists {
+			problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
+			return
+		}
+
+		user, err := interactor.GetById(c.Request.Context(), id.(*entity.UserID))
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				problem.AbortWithStatus(c, http.StatusUnauthorized, nil)
+				return
+			}
+			problem.Abort(c, fmt.Errorf("can't get user: %w", err))
+			return
+		}
+
+		if user.DisabledAt != nil {
+			problem.Abort(c, entity.ErrUserDisabled)
+			return
+		}
+
+		for _, role := range roles {
+			if user.Role == role {
+				c.Next()
+				return
+			}
+		}
+
+		problem.AbortWithStatus(c, http.StatusForbidden, nil)
+	}
+}
@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/entity"
+)
+
+type exportPresenter struct {
+}
+
+func NewExportPresenter() *exportPresenter {
+	return &exportPresenter{}
+}
+
+func (e *exportPresenter) ToExportJobView(job *entity.ExportJob) *view.ExportJobView {
+	res := &view.ExportJobView{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		Format:    string(job.Options.Format),
+		Columns:   job.Options.Columns,
+		Rows:      job.Rows,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt,
+	}
+	if !job.FinishedAt.IsZero() {
+		finishedAt := job.FinishedAt
+		res.FinishedAt = &finishedAt
+	}
+
+	return res
+}
@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/entity"
+)
+
+type importPresenter struct {
+}
+
+func NewImportPresenter() *importPresenter {
+	return &importPresenter{}
+}
+
+func (i *importPresenter) ToUserImportReportView(report *entity.UserImportReport) *view.UserImportReportView {
+	results := make([]*view.UserImportResultView, 0, len(report.Results))
+	for _, result := range report.Results {
+		resultView := &view.UserImportResultView{
+			Line:   result.Line,
+			Email:  result.Email,
+			Status: string(result.Status),
+			Error:  result.Error,
+		}
+		if result.ID != nil {
+			resultView.ID = result.ID.String()
+		}
+		results = append(results, resultView)
+	}
+
+	return &view.UserImportReportView{
+		Mode:      string(report.Mode),
+		DryRun:    report.DryRun,
+		Committed: report.Committed,
+		Total:     report.Total,
+		Created:   report.Created,
+		Failed:    report.Failed,
+		Results:   results,
+	}
+}
@@ -8,9 +8,20 @@ import (
 //go:generate mockgen -source=./interfaces.go -destination=./presenter_mock.go -package=presenter
 
 type UserPresenter interface {
-	ToUserView(user *entity.User) *view.UserView
+	ToUserView(user *entity.User, audience entity.UserAudience) *view.UserView
+	ToUserCreate(request *view.UserCreateRequest) *entity.UserCreate
+	ToUserHistoryView(page *entity.UserHistoryPage, audience entity.UserAudience) *view.UserHistoryView
+	ToUserDataArchiveView(archive *entity.UserDataArchive) *view.UserDataArchiveView
 }
 
 type TokenPresenter interface {
 	ToTokenView(token *entity.Token) (*view.TokenView, error)
 }
+
+type ImportPresenter interface {
+	ToUserImportReportView(report *entity.UserImportReport) *view.UserImportReportView
+}
+
+type ExportPresenter interface {
+	ToExportJobView(job *entity.ExportJob) *view.ExportJobView
+}
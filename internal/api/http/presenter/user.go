@@ -13,12 +13,137 @@ func NewUserPresenter() *userPresenter {
 	return &userPresenter{}
 }
 
-func (u *userPresenter) ToUserView(user *entity.User) *view.UserView {
-	return &view.UserView{
-		ID:    user.ID.String(),
-		Name:  fmt.Sprintf("%s %s %s", user.LastName, user.FirstName, user.SecondName),
-		Age:   user.Age,
-		Email: user.Email,
-		Phone: user.Phone,
+// ToUserView возвращает поля пользователя, доступные аудитории. Пароль не передается.
+func (u *userPresenter) ToUserView(user *entity.User, audience entity.UserAudience) *view.UserView {
+	res := &view.UserView{
+		ID:        user.ID.String(),
+		Name:      fmt.Sprintf("%s %s %s", user.LastName, user.FirstName, user.SecondName),
+		CreatedAt: user.CreatedAt,
 	}
+	if audience == entity.UserAudienceOther {
+		return res
+	}
+
+	age := user.Age
+	updatedAt := user.UpdatedAt
+	res.Age = &age
+	res.Email = user.Email
+	res.Phone = user.Phone
+	res.UpdatedAt = &updatedAt
+	if audience != entity.UserAudienceAdmin {
+		return res
+	}
+
+	res.Role = string(user.Role)
+	res.ErasedAt = user.ErasedAt
+
+	return res
+}
+
+func (u *userPresenter) ToUserCreate(request *view.UserCreateRequest) *entity.UserCreate {
+	return &entity.UserCreate{
+		FirstName:  request.FirstName,
+		SecondName: request.SecondName,
+		LastName:   request.LastName,
+		Password:   request.Password,
+		Age:        request.Age,
+		Email:      request.Email,
+		Phone:      request.Phone,
+	}
+}
+
+// ToUserHistoryView возвращает страницу истории, снимки в которой содержат поля, доступные аудитории
+func (u *userPresenter) ToUserHistoryView(page *entity.UserHistoryPage, audience entity.UserAudience) *view.UserHistoryView {
+	return &view.UserHistoryView{
+		Entries:    toUserHistoryEntryViews(page.Entries, audience),
+		NextCursor: page.NextCursor,
+	}
+}
+
+func (u *userPresenter) ToUserDataArchiveView(archive *entity.UserDataArchive) *view.UserDataArchiveView {
+	profile := archive.Profile
+	return &view.UserDataArchiveView{
+		ExportedAt: archive.ExportedAt,
+		Profile: &view.UserProfileView{
+			ID:         profile.ID.String(),
+			FirstName:  profile.FirstName,
+			SecondName: profile.SecondName,
+			LastName:   profile.LastName,
+			Age:        profile.Age,
+			Email:      profile.Email,
+			Phone:      profile.Phone,
+			Role:       string(profile.Role),
+			CreatedAt:  profile.CreatedAt,
+			UpdatedAt:  profile.UpdatedAt,
+			ErasedAt:   profile.ErasedAt,
+		},
+		// Архив содержит все хранимые данные, кроме пароля
+		History:  toUserHistoryEntryViews(archive.History, entity.UserAudienceAdmin),
+		Audit:    toUserHistoryEntryViews(archive.Audit, entity.UserAudienceAdmin),
+		Sessions: toUserSessionViews(archive.Sessions),
+	}
+}
+
+func toUserSessionViews(sessions []*entity.UserSession) []*view.UserSessionView {
+	res := make([]*view.UserSessionView, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, &view.UserSessionView{
+			ID:        session.ID,
+			IssuedAt:  session.IssuedAt,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+
+	return res
+}
+
+func toUserHistoryEntryViews(entries []*entity.UserHistoryEntry, audience entity.UserAudience) []*view.UserHistoryEntryView {
+	res := make([]*view.UserHistoryEntryView, 0, len(entries))
+	for _, entry := range entries {
+		entryView := &view.UserHistoryEntryView{
+			ID:        entry.ID,
+			UserID:    entry.UserID.String(),
+			Action:    string(entry.Action),
+			Before:    toUserSnapshotView(entry.Before, audience),
+			After:     toUserSnapshotView(entry.After, audience),
+			CreatedAt: entry.CreatedAt,
+		}
+		if entry.ActorID != nil {
+			entryView.ActorID = entry.ActorID.String()
+		}
+		res = append(res, entryView)
+	}
+
+	return res
+}
+
+// toUserSnapshotView возвращает поля снимка, доступные аудитории, по тем же правилам, что и ToUserView
+func toUserSnapshotView(snapshot *entity.UserSnapshot, audience entity.UserAudience) *view.UserSnapshotView {
+	if snapshot == nil {
+		return nil
+	}
+
+	res := &view.UserSnapshotView{
+		FirstName:  snapshot.FirstName,
+		SecondName: snapshot.SecondName,
+		LastName:   snapshot.LastName,
+		CreatedAt:  snapshot.CreatedAt,
+	}
+	if audience == entity.UserAudienceOther {
+		return res
+	}
+
+	age := snapshot.Age
+	updatedAt := snapshot.UpdatedAt
+	res.Age = &age
+	res.Email = snapshot.Email
+	res.Phone = snapshot.Phone
+	res.UpdatedAt = &updatedAt
+	if audience != entity.UserAudienceAdmin {
+		return res
+	}
+
+	res.Role = string(snapshot.Role)
+
+	return res
 }
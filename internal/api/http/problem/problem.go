@@ -0,0 +1,107 @@
+// Package problem прерывает обработку HTTP-запроса с ошибкой и формирует ответ в формате RFC 7807.
+//
+//	Обработчики не пишут статус сами, а сохраняют ошибку в контексте gin через Abort/AbortWithStatus.
+//	Ответ формирует middlewares.NewErrorMiddleware после выполнения всей цепочки обработчиков.
+package problem
+
+import (
+	"errors"
+	"go-test-grpc-http/internal/api/http/view"
+	"go-test-grpc-http/internal/domain"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ContentType = "application/problem+json"
+
+	defaultType   = "about:blank"
+	typePrefix    = "urn:problem-type:"
+	defaultDetail = "Что-то пошло не так. Попробуйте еще раз."
+)
+
+// statusError ошибка с явно заданным HTTP-статусом
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// Abort прерывает запрос со статусом, соответствующим виду ошибки предметной области
+func Abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// AbortWithStatus прерывает запрос с явно заданным статусом. err может быть nil.
+func AbortWithStatus(c *gin.Context, status int, err error) {
+	Abort(c, WithStatus(status, err))
+}
+
+// WithStatus задает ошибке HTTP-статус, который будет использован вместо статуса по виду ошибки
+func WithStatus(status int, err error) error {
+	if err == nil {
+		err = errors.New(strings.ToLower(http.StatusText(status)))
+	}
+
+	return &statusError{
+		status: status,
+		err:    err,
+	}
+}
+
+// Status возвращает HTTP-статус ошибки
+func Status(err error) int {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status
+	}
+
+	return domain.HTTPStatus(err)
+}
+
+// New формирует описание ошибки для ответа.
+//
+//	Текст внутренних ошибок (статус 5xx) в ответ не попадает.
+func New(c *gin.Context, err error, requestID string) *view.ProblemView {
+	status := Status(err)
+	res := &view.ProblemView{
+		Type:      defaultType,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Instance:  c.Request.URL.Path,
+		RequestID: requestID,
+	}
+
+	if domain.IsKnown(err) {
+		res.Type = typePrefix + strings.ReplaceAll(strings.ToLower(domain.Reason(err)), "_", "-")
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		res.Detail = defaultDetail
+		return res
+	case domain.IsKnown(err):
+		res.Detail = domain.PublicMessage(err)
+	default:
+		res.Detail = err.Error()
+	}
+
+	for _, violation := range domain.ViolationsOf(err) {
+		res.Violations = append(res.Violations, &view.ViolationView{
+			Field:   violation.Field,
+			Message: violation.Description,
+		})
+	}
+
+	return res
+}
@@ -2,51 +2,70 @@ package http
 
 import (
 	"fmt"
-	"go-test-grpc-http/docs"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
 	"go-test-grpc-http/internal/api/http/handlers"
 	"go-test-grpc-http/internal/api/http/middlewares"
-	"go-test-grpc-http/internal/api/http/presenter"
-	"go-test-grpc-http/internal/db"
-	"go-test-grpc-http/internal/repository"
+	"go-test-grpc-http/internal/api/http/problem"
+	"go-test-grpc-http/internal/health"
+	"go-test-grpc-http/internal/metrics"
+	"go-test-grpc-http/internal/ratelimit"
 	"go-test-grpc-http/internal/usecase"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jmoiron/sqlx"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 
 	"github.com/gin-contrib/cors"
 )
 
-type routerHandlers struct {
-	userHandlers handlers.UserHandlers
-	authHandlers handlers.AuthHandlers
-}
-
 type router struct {
-	router   *gin.Engine
-	db       *sqlx.DB
-	handlers routerHandlers
-	logger   *zap.Logger
+	router *gin.Engine
+	// userInteractor общий для всех транспортов сценарий работы с пользователями
+	userInteractor usecase.UserInteractor
+	// exportJobs фоновые выгрузки, останавливаются вместе с приложением
+	exportJobs usecase.ExportJobInteractor
+	health     *health.Registry
+	limiter    *ratelimit.Limiter
+	grpc       GRPCHandlers
+	logger     *zap.Logger
 }
 
-func NewRouter(db *sqlx.DB, logger *zap.Logger) *router {
+func NewRouter(
+	userInteractor usecase.UserInteractor,
+	exportJobs usecase.ExportJobInteractor,
+	health *health.Registry,
+	limiter *ratelimit.Limiter,
+	grpc GRPCHandlers,
+	logger *zap.Logger,
+) *router {
 	return &router{
-		router: gin.New(),
-		db:     db,
-		logger: logger,
+		router:         gin.New(),
+		userInteractor: userInteractor,
+		health:         health,
+		limiter:        limiter,
+		exportJobs:     exportJobs,
+		grpc:           grpc,
+		logger:         logger,
 	}
 }
 
 func (r *router) Init() error {
+	cfg, err := config.GetAppConfig()
+	if err != nil {
+		return fmt.Errorf("can't get config: %w", err)
+	}
+
 	r.router.Use(
+		otelgin.Middleware(cfg.AppInfo.Name),
 		gin.Logger(),
+		middlewares.NewRequestIDMiddleware(),
+		middlewares.NewMetricsMiddleware(),
+		middlewares.NewErrorMiddleware(r.logger),
 		gin.CustomRecovery(r.recovery),
 	)
-	err := r.registerRoutes()
+	err = r.registerRoutes()
 	if err != nil {
 		return fmt.Errorf("can't init router: %w", err)
 	}
@@ -60,59 +79,51 @@ func (r *router) recovery(c *gin.Context, recovered any) {
 			r.logger.Fatal("http server panic", zap.Error(fmt.Errorf("%s", recovered)))
 		}
 	}()
-	c.AbortWithStatus(http.StatusInternalServerError)
+	problem.AbortWithStatus(c, http.StatusInternalServerError, fmt.Errorf("panic: %v", recovered))
 }
 
 func (r *router) registerRoutes() error {
+	cfg, err := config.GetAppConfig()
+	if err != nil {
+		return fmt.Errorf("can't get config: %w", err)
+	}
+
 	r.router.NoMethod(handlers.NotImplementedHandler)
 	r.router.NoRoute(handlers.NotImplementedHandler)
 
 	corsMiddleware := cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
+		AllowOriginFunc:  config.AllowOrigin,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "Connect-Protocol-Version", "Connect-Timeout-Ms"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
 
 	r.router.Use(corsMiddleware)
 
-	basePath := r.router.Group(docs.SwaggerInfo.BasePath)
+	r.router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
-	basePath.GET("/swagger/swagger.json", func(c *gin.Context) {
-		c.File("docs/swagger.json")
-	})
-	basePath.GET("/swagger/swagger.yaml", func(c *gin.Context) {
-		c.File("docs/swagger.yaml")
-	})
-	basePath.GET("/docs/*any", ginSwagger.WrapHandler(
-		swaggerFiles.Handler,
-		ginSwagger.URL("http://"+docs.SwaggerInfo.Host+docs.SwaggerInfo.BasePath+"/swagger/swagger.json"),
-	),
-	)
+	var healthHandlers handlers.HealthHandlers = handlers.NewHealthHandlers(r.health)
+	r.router.GET("/healthz", healthHandlers.Healthz)
+	r.router.GET("/readyz", healthHandlers.Readyz)
+
+	// Ограничение частоты не распространяется на служебные маршруты выше
+	r.router.Use(middlewares.NewRateLimitMiddleware(r.limiter))
+
+	deps := apiDependencies{
+		userInteractor: r.userInteractor,
+		exportJobs:     r.exportJobs,
+	}
+
+	for _, version := range apiVersions(cfg) {
+		mountVersion(r.router, version, deps)
+	}
 
-	pgSource := db.NewSource(r.db)
-	userRepository := repository.NewUserRepository(pgSource)
-	userInteractor := usecase.NewUserInteractor(userRepository)
-	userPresenter := presenter.NewUserPresenter()
-	tokenPresenter := presenter.NewTokenPresenter()
-	r.handlers.authHandlers = handlers.NewAuthHandlers(userInteractor, tokenPresenter)
-
-	authGroup := basePath.Group("/auth")
-	authGroup.POST("/signup", r.handlers.authHandlers.SignUp)
-	authGroup.POST("/signin", r.handlers.authHandlers.SignIn)
-
-	userGroup := basePath.Group("/users")
-	{
-		userGroup.Use(middlewares.NewAuthMiddleware())
-		r.handlers.userHandlers = handlers.NewUserHandlers(userInteractor, userPresenter)
-		userGroup.GET("/me", r.handlers.userHandlers.GetMeHandler)
-		userGroup.PUT("/me", r.handlers.userHandlers.UpdateMeHandler)
-		userGroup.DELETE("/me", r.handlers.userHandlers.DeleteMeHandler)
-		userGroup.GET("/id/:id", r.handlers.userHandlers.GetByIdHandler)
-		userGroup.GET("/email/:email", r.handlers.userHandlers.GetByEmailHandler)
-		userGroup.PUT("/id/:id", r.handlers.userHandlers.UpdateHandler)
-		userGroup.DELETE("/id/:id", r.handlers.userHandlers.DeleteHandler)
+	if r.grpc.Gateway != nil {
+		mountGateway(r.router, cfg.Gateway.Prefix, r.grpc.Gateway)
+	}
+	if r.grpc.Connect != nil {
+		mountConnect(r.router, cfg.Connect.Prefix, r.grpc.Connect)
 	}
 
 	return nil
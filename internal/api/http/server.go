@@ -2,11 +2,14 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go-test-grpc-http/internal/health"
+	"go-test-grpc-http/internal/ratelimit"
+	"go-test-grpc-http/internal/usecase"
 	"net/http"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
@@ -21,22 +24,23 @@ type Server interface {
 
 type server struct {
 	server *http.Server
-	db     *sqlx.DB
 	logger *zap.Logger
 }
 
 func NewServer(
 	addr string,
-	db *sqlx.DB,
+	userInteractor usecase.UserInteractor,
+	exportJobs usecase.ExportJobInteractor,
+	health *health.Registry,
+	limiter *ratelimit.Limiter,
+	grpc GRPCHandlers,
 	logger *zap.Logger,
 ) *server {
 	s := &server{
-		db:     db,
 		logger: logger,
 	}
 
-	r := NewRouter(db, logger)
-	err := r.Init()
+	handler, err := NewHandler(userInteractor, exportJobs, health, limiter, grpc, logger)
 	if err != nil {
 		s.logger.Error("can't init router:", zap.Error(err))
 		return nil
@@ -44,7 +48,7 @@ func NewServer(
 
 	httpServer := &http.Server{
 		Addr:              addr,
-		Handler:           r.router,
+		Handler:           handler,
 		ReadHeaderTimeout: RequestTimeOut,
 	}
 	s.server = httpServer
@@ -52,23 +56,41 @@ func NewServer(
 	return s
 }
 
-func (s *server) Run(ctx context.Context) error {
-	go func() {
-		<-ctx.Done()
-		err := s.server.Shutdown(ctx)
-		if err != nil {
-			s.logger.Error("can't shutdown http-server", zap.Error(err))
-			return
-		}
-	}()
+// NewHandler создает обработчик HTTP API со всеми маршрутами
+func NewHandler(
+	userInteractor usecase.UserInteractor,
+	exportJobs usecase.ExportJobInteractor,
+	health *health.Registry,
+	limiter *ratelimit.Limiter,
+	grpc GRPCHandlers,
+	logger *zap.Logger,
+) (http.Handler, error) {
+	r := NewRouter(userInteractor, exportJobs, health, limiter, grpc, logger)
+	err := r.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.router, nil
+}
+
+// Run обслуживает запросы до вызова Shutdown
+func (s *server) Run(_ context.Context) error {
+	err := s.server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
 
-	return s.server.ListenAndServe()
+	return err
 }
 
+// Shutdown дожидается завершения текущих запросов. Если срок ctx истек раньше,
+// оставшиеся соединения закрываются принудительно.
 func (s *server) Shutdown(ctx context.Context) error {
 	err := s.server.Shutdown(ctx)
 	if err != nil {
-		return fmt.Errorf("http server shutdown error: %w", err)
+		s.server.Close()
+		return fmt.Errorf("http server stopped forcibly: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/docs"
+	"go-test-grpc-http/internal/api/http/handlers"
+	"go-test-grpc-http/internal/api/http/middlewares"
+	"go-test-grpc-http/internal/api/http/presenter"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// Базовые пути версий HTTP API
+const (
+	V1BasePath     = "/api/v1"
+	LegacyBasePath = "/api/v0.0.1"
+)
+
+// apiDependencies общие для всех версий API сценарии использования
+type apiDependencies struct {
+	userInteractor usecase.UserInteractor
+	exportJobs     usecase.ExportJobInteractor
+}
+
+// apiVersion версия HTTP API. Каждая версия сама создает свои обработчики и презентеры,
+// поэтому новая версия может менять представление данных, не затрагивая предыдущие
+type apiVersion struct {
+	basePath string
+	register func(group *gin.RouterGroup, deps apiDependencies)
+	// deprecation заполняется только для устаревших версий
+	deprecation *middlewares.Deprecation
+}
+
+// apiVersions возвращает все обслуживаемые версии API. Сроки вывода из эксплуатации
+// устаревшего префикса LegacyBasePath задаются в cfg.
+func apiVersions(cfg *config.Config) []apiVersion {
+	legacyDeprecatedAt, legacySunsetAt := cfg.LegacyAPIDates()
+
+	return []apiVersion{
+		{
+			basePath: V1BasePath,
+			register: registerV1,
+		},
+		{
+			// Исторический префикс, совпадающий по контракту с v1
+			basePath: LegacyBasePath,
+			register: registerV1,
+			deprecation: &middlewares.Deprecation{
+				At:        legacyDeprecatedAt,
+				Sunset:    legacySunsetAt,
+				Successor: V1BasePath,
+			},
+		},
+	}
+}
+
+// mountVersion регистрирует маршруты версии API и документацию к ней
+func mountVersion(engine *gin.Engine, version apiVersion, deps apiDependencies) {
+	group := engine.Group(version.basePath)
+	if version.deprecation != nil {
+		group.Use(middlewares.NewDeprecationMiddleware(*version.deprecation))
+	}
+
+	group.GET("/swagger/swagger.json", func(c *gin.Context) {
+		c.File("docs/swagger.json")
+	})
+	group.GET("/swagger/swagger.yaml", func(c *gin.Context) {
+		c.File("docs/swagger.yaml")
+	})
+	group.GET("/docs/*any", ginSwagger.WrapHandler(
+		swaggerFiles.Handler,
+		ginSwagger.URL("http://"+docs.SwaggerInfo.Host+docs.SwaggerInfo.BasePath+"/swagger/swagger.json"),
+	),
+	)
+
+	version.register(group, deps)
+}
+
+// registerV1 регистрирует маршруты версии v1
+func registerV1(group *gin.RouterGroup, deps apiDependencies) {
+	userPresenter := presenter.NewUserPresenter()
+	tokenPresenter := presenter.NewTokenPresenter()
+	importPresenter := presenter.NewImportPresenter()
+	exportPresenter := presenter.NewExportPresenter()
+
+	var (
+		authHandlers  handlers.AuthHandlers  = handlers.NewAuthHandlers(deps.userInteractor, tokenPresenter, userPresenter)
+		userHandlers  handlers.UserHandlers  = handlers.NewUserHandlers(deps.userInteractor, userPresenter)
+		adminHandlers handlers.AdminHandlers = handlers.NewAdminHandlers(deps.userInteractor, deps.exportJobs, userPresenter, importPresenter, exportPresenter)
+	)
+
+	authGroup := group.Group("/auth")
+	authGroup.POST("/signup", authHandlers.SignUp)
+	authGroup.POST("/signin", authHandlers.SignIn)
+
+	userGroup := group.Group("/users")
+	{
+		userGroup.Use(middlewares.NewAuthMiddleware(deps.userInteractor))
+		userGroup.GET("/me", userHandlers.GetMeHandler)
+		userGroup.PUT("/me", userHandlers.UpdateMeHandler)
+		userGroup.DELETE("/me", userHandlers.DeleteMeHandler)
+		userGroup.GET("/me/export", userHandlers.ExportMeHandler)
+		userGroup.POST("/me/erase", userHandlers.EraseMeHandler)
+		userGroup.GET("/id/:id", userHandlers.GetByIdHandler)
+		userGroup.GET("/email/:email", userHandlers.GetByEmailHandler)
+		userGroup.PUT("/id/:id", userHandlers.UpdateHandler)
+		userGroup.DELETE("/id/:id", userHandlers.DeleteHandler)
+		userGroup.GET("/id/:id/history", userHandlers.GetHistoryHandler)
+	}
+
+	adminGroup := group.Group("/admin")
+	{
+		adminGroup.Use(
+			middlewares.NewAuthMiddleware(deps.userInteractor),
+			middlewares.NewRoleMiddleware(deps.userInteractor, entity.RoleAdmin),
+		)
+		adminGroup.POST("/users/import", adminHandlers.ImportUsersHandler)
+		adminGroup.GET("/users/export", adminHandlers.ExportUsersHandler)
+		adminGroup.POST("/users/export/jobs", adminHandlers.StartExportJobHandler)
+		adminGroup.GET("/users/export/jobs/:id", adminHandlers.GetExportJobHandler)
+		adminGroup.GET("/users/export/jobs/:id/file", adminHandlers.DownloadExportJobHandler)
+		adminGroup.GET("/users/id/:id/export", adminHandlers.ExportUserDataHandler)
+		adminGroup.POST("/users/id/:id/erase", adminHandlers.EraseUserHandler)
+	}
+}
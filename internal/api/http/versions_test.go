@@ -0,0 +1,56 @@
+package http
+
+import (
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/api/http/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestApiVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{}
+	cfg.LegacyAPI.DeprecatedAt = "2030-01-01"
+	cfg.LegacyAPI.SunsetAt = "2030-07-01"
+
+	engine := gin.New()
+	engine.Use(middlewares.NewErrorMiddleware(zap.NewNop()))
+	for _, version := range apiVersions(cfg) {
+		mountVersion(engine, version, apiDependencies{})
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		deprecated bool
+	}{
+		{name: "v1", path: V1BasePath, deprecated: false},
+		{name: "legacy", path: LegacyBasePath, deprecated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path+"/auth/signin", strings.NewReader("{}"))
+			engine.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+			}
+			if got := w.Header().Get("Deprecation") != ""; got != tt.deprecated {
+				t.Errorf("deprecated = %v, want %v", got, tt.deprecated)
+			}
+			if tt.deprecated && w.Header().Get("Sunset") != "Mon, 01 Jul 2030 00:00:00 GMT" {
+				t.Errorf("unexpected Sunset header %q", w.Header().Get("Sunset"))
+			}
+			if tt.deprecated && w.Header().Get("Link") != `</api/v1>; rel="successor-version"` {
+				t.Errorf("unexpected Link header %q", w.Header().Get("Link"))
+			}
+		})
+	}
+}
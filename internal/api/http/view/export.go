@@ -0,0 +1,14 @@
+package view
+
+import "time"
+
+type ExportJobView struct {
+	ID         string     `json:"id"`                    // ID задачи
+	Status     string     `json:"status"`                // Статус: running, done, failed
+	Format     string     `json:"format"`                // Формат файла: csv, ndjson
+	Columns    []string   `json:"columns"`               // Экспортируемые колонки
+	Rows       int        `json:"rows"`                  // Количество выгруженных пользователей
+	Error      string     `json:"error,omitempty"`       // Описание ошибки
+	CreatedAt  time.Time  `json:"created_at"`            // Время создания
+	FinishedAt *time.Time `json:"finished_at,omitempty"` // Время завершения
+}
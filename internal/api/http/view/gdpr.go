@@ -0,0 +1,31 @@
+package view
+
+import "time"
+
+type UserProfileView struct {
+	ID         string     `json:"id"`                  // ID
+	FirstName  string     `json:"first_name"`          // Имя
+	SecondName string     `json:"second_name"`         // Отчество
+	LastName   string     `json:"last_name"`           // Фамилия
+	Age        int        `json:"age"`                 // Возраст
+	Email      string     `json:"email"`               // Электронная почта
+	Phone      string     `json:"phone"`               // Номер мобильного телефона
+	Role       string     `json:"role"`                // Роль
+	CreatedAt  time.Time  `json:"created_at"`          // Время создания
+	UpdatedAt  time.Time  `json:"updated_at"`          // Время последнего изменения
+	ErasedAt   *time.Time `json:"erased_at,omitempty"` // Время удаления персональных данных
+}
+
+type UserDataArchiveView struct {
+	ExportedAt time.Time               `json:"exported_at"` // Время формирования архива
+	Profile    *UserProfileView        `json:"profile"`     // Профиль
+	History    []*UserHistoryEntryView `json:"history"`     // История изменений профиля
+	Audit      []*UserHistoryEntryView `json:"audit"`       // Действия пользователя над другими записями
+	Sessions   []*UserSessionView      `json:"sessions"`    // Сеансы, всегда пусто: JWT не хранятся на сервере
+}
+
+type UserSessionView struct {
+	ID        string    `json:"id"`         // ID токена
+	IssuedAt  time.Time `json:"issued_at"`  // Время выдачи
+	ExpiresAt time.Time `json:"expires_at"` // Время окончания действия
+}
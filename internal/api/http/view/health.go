@@ -0,0 +1,11 @@
+package view
+
+type HealthView struct {
+	Status string                     `json:"status"`           // Состояние приложения: SERVING или NOT_SERVING
+	Checks map[string]HealthCheckView `json:"checks,omitempty"` // Состояние подсистем
+}
+
+type HealthCheckView struct {
+	Status string `json:"status"`          // Состояние подсистемы: SERVING или NOT_SERVING
+	Error  string `json:"error,omitempty"` // Причина неготовности
+}
@@ -0,0 +1,31 @@
+package view
+
+import "time"
+
+// UserSnapshotView данные пользователя в записи истории. Состав полей зависит от вызывающего так же, как у UserView.
+type UserSnapshotView struct {
+	FirstName  string     `json:"first_name"`           // Имя
+	SecondName string     `json:"second_name"`          // Отчество
+	LastName   string     `json:"last_name"`            // Фамилия
+	Age        *int       `json:"age,omitempty"`        // Возраст
+	Email      string     `json:"email,omitempty"`      // Электронная почта
+	Phone      string     `json:"phone,omitempty"`      // Номер мобильного телефона
+	Role       string     `json:"role,omitempty"`       // Роль
+	CreatedAt  time.Time  `json:"created_at"`           // Время создания
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"` // Время последнего изменения
+}
+
+type UserHistoryEntryView struct {
+	ID        int64             `json:"id"`                 // ID записи
+	UserID    string            `json:"user_id"`            // ID пользователя, к которому относится запись
+	Action    string            `json:"action"`             // Действие: create, update, delete
+	ActorID   string            `json:"actor_id,omitempty"` // ID пользователя, выполнившего действие
+	Before    *UserSnapshotView `json:"before,omitempty"`   // Данные до изменения
+	After     *UserSnapshotView `json:"after,omitempty"`    // Данные после изменения
+	CreatedAt time.Time         `json:"created_at"`         // Время изменения
+}
+
+type UserHistoryView struct {
+	Entries    []*UserHistoryEntryView `json:"entries"`               // Записи от новых к старым
+	NextCursor int64                   `json:"next_cursor,omitempty"` // Курсор следующей страницы
+}
@@ -0,0 +1,19 @@
+package view
+
+type UserImportResultView struct {
+	Line   int    `json:"line"`            // Номер строки во входных данных
+	Email  string `json:"email,omitempty"` // Электронная почта
+	ID     string `json:"id,omitempty"`    // ID созданного пользователя
+	Status string `json:"status"`          // Статус строки: created, valid, failed, rolled_back
+	Error  string `json:"error,omitempty"` // Описание ошибки
+}
+
+type UserImportReportView struct {
+	Mode      string                  `json:"mode"`      // Поведение при ошибках: all-or-nothing, best-effort
+	DryRun    bool                    `json:"dry_run"`   // Импорт выполнен без сохранения
+	Committed bool                    `json:"committed"` // Изменения сохранены в бд
+	Total     int                     `json:"total"`     // Всего строк
+	Created   int                     `json:"created"`   // Создано пользователей
+	Failed    int                     `json:"failed"`    // Строк с ошибками
+	Results   []*UserImportResultView `json:"results"`   // Результаты по строкам
+}
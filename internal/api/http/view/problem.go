@@ -0,0 +1,17 @@
+package view
+
+// ProblemView описание ошибки в формате RFC 7807 (application/problem+json)
+type ProblemView struct {
+	Type       string           `json:"type"`                 // URI вида ошибки, about:blank для ошибок без вида
+	Title      string           `json:"title"`                // Краткое описание вида ошибки
+	Status     int              `json:"status"`               // HTTP-статус
+	Detail     string           `json:"detail,omitempty"`     // Описание конкретной ошибки
+	Instance   string           `json:"instance"`             // Путь запроса, при обработке которого возникла ошибка
+	RequestID  string           `json:"request_id,omitempty"` // ID запроса для поиска в логах
+	Violations []*ViolationView `json:"violations,omitempty"` // Нарушения ограничений по полям
+}
+
+type ViolationView struct {
+	Field   string `json:"field"`   // Путь к полю
+	Message string `json:"message"` // Описание нарушения
+}
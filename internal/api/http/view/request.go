@@ -0,0 +1,20 @@
+package view
+
+// UserCreateRequest данные пользователя для регистрации и обновления.
+//
+//	Правила проверки соответствуют usecase.ValidateUserCreate и правилам в proto.
+type UserCreateRequest struct {
+	FirstName  string `json:"firstName" validate:"required,max=50"`    // Имя
+	SecondName string `json:"secondName" validate:"max=50"`            // Отчество
+	LastName   string `json:"lastName" validate:"required,max=50"`     // Фамилия
+	Password   string `json:"password" validate:"required,max=255"`    // Пароль
+	Age        int    `json:"age" validate:"gte=0,lte=150"`            // Возраст
+	Email      string `json:"email" validate:"required,email,max=255"` // Электронная почта
+	Phone      string `json:"phone" validate:"omitempty,phone"`        // Номер телефона в формате E.164
+}
+
+// SignInRequest данные для входа
+type SignInRequest struct {
+	Email    string `json:"email" validate:"required,email"` // Электронная почта
+	Password string `json:"password" validate:"required"`    // Пароль
+}
@@ -1,9 +1,17 @@
 package view
 
+import "time"
+
+// UserView данные пользователя. Состав полей зависит от вызывающего: другим пользователям
+// показываются только id, name и created_at, администратору - также role и erased_at.
 type UserView struct {
-	ID    string `json:"id"`    // ID
-	Name  string `json:"name"`  // Имя в формате ФИО
-	Age   int    `json:"age"`   // Возраст
-	Email string `json:"email"` // Электронная почта
-	Phone string `json:"phone"` // Номер мобильного телефона
+	ID        string     `json:"id"`                   // ID
+	Name      string     `json:"name"`                 // Имя в формате ФИО
+	Age       *int       `json:"age,omitempty"`        // Возраст
+	Email     string     `json:"email,omitempty"`      // Электронная почта
+	Phone     string     `json:"phone,omitempty"`      // Номер мобильного телефона
+	Role      string     `json:"role,omitempty"`       // Роль
+	CreatedAt time.Time  `json:"created_at"`           // Время создания
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // Время последнего изменения
+	ErasedAt  *time.Time `json:"erased_at,omitempty"`  // Время удаления персональных данных
 }
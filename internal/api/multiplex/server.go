@@ -0,0 +1,126 @@
+package multiplex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+)
+
+const RequestTimeOut = 30 * time.Second
+
+// GRPCServer gRPC-сервер, обслуживающий часть соединений общего порта
+type GRPCServer interface {
+	Serve(ctx context.Context, lis net.Listener) error
+	WithGrpcWeb(next http.Handler) http.Handler
+	Shutdown(ctx context.Context) error
+}
+
+// server обслуживает gRPC, gRPC-Web и HTTP на одном порту.
+//
+//	Соединения HTTP/2 с content-type application/grpc передаются gRPC-серверу,
+//	остальные - HTTP-серверу, который отдает запросы gRPC-Web gRPC-серверу, а прочие - handler.
+type server struct {
+	addr     string
+	grpc     GRPCServer
+	http     *http.Server
+	listener net.Listener
+	logger   *zap.Logger
+}
+
+func NewServer(addr string, grpcServer GRPCServer, handler http.Handler, logger *zap.Logger) *server {
+	return &server{
+		addr: addr,
+		grpc: grpcServer,
+		http: &http.Server{
+			Handler:           grpcServer.WithGrpcWeb(handler),
+			ReadHeaderTimeout: RequestTimeOut,
+		},
+		logger: logger,
+	}
+}
+
+func (s *server) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("multiplex listen error: %w", err)
+	}
+
+	return s.Serve(ctx, lis)
+}
+
+// Serve разделяет соединения слушателя lis между gRPC- и HTTP-серверами до вызова Shutdown
+func (s *server) Serve(ctx context.Context, lis net.Listener) error {
+	s.listener = lis
+
+	m := cmux.New(lis)
+	grpcListener := m.MatchWithWriters(
+		cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"),
+		cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc+proto"),
+	)
+	httpListener := m.Match(cmux.Any())
+
+	errs := make(chan error, 3)
+	go func() {
+		errs <- s.grpc.Serve(ctx, grpcListener)
+	}()
+	go func() {
+		errs <- s.http.Serve(httpListener)
+	}()
+	go func() {
+		errs <- m.Serve()
+	}()
+
+	err := <-errs
+	if isClosed(err) {
+		return nil
+	}
+	return fmt.Errorf("multiplex serve error: %w", err)
+}
+
+// Shutdown параллельно останавливает HTTP- и gRPC-серверы, дожидаясь завершения текущих запросов, и закрывает порт.
+// Если срок ctx истек раньше, оставшиеся соединения закрываются принудительно.
+func (s *server) Shutdown(ctx context.Context) error {
+	httpErr := make(chan error, 1)
+	go func() {
+		// Слушатели cmux разделяют общий порт, поэтому его повторное закрытие не считается ошибкой
+		err := s.http.Shutdown(ctx)
+		if isClosed(err) {
+			err = nil
+		}
+		if err != nil {
+			s.http.Close()
+			err = fmt.Errorf("http server stopped forcibly: %w", err)
+		}
+		httpErr <- err
+	}()
+
+	var errs []error
+	if err := s.grpc.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("grpc server shutdown error: %w", err))
+	}
+	if err := <-httpErr; err != nil {
+		errs = append(errs, err)
+	}
+	if s.listener != nil {
+		err := s.listener.Close()
+		if err != nil && !isClosed(err) {
+			errs = append(errs, fmt.Errorf("can't close listener: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isClosed(err error) bool {
+	return err == nil ||
+		errors.Is(err, http.ErrServerClosed) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, cmux.ErrListenerClosed) ||
+		errors.Is(err, cmux.ErrServerClosed)
+}
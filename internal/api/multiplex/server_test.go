@@ -0,0 +1,84 @@
+package multiplex
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testGRPCServer struct {
+	server *grpc.Server
+}
+
+func (s *testGRPCServer) Serve(_ context.Context, lis net.Listener) error {
+	return s.server.Serve(lis)
+}
+
+func (s *testGRPCServer) WithGrpcWeb(next http.Handler) http.Handler {
+	return next
+}
+
+func (s *testGRPCServer) Shutdown(_ context.Context) error {
+	s.server.GracefulStop()
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, "http")
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	s := NewServer(lis.Addr().String(), &testGRPCServer{server: grpcServer}, handler, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx, lis)
+	}()
+
+	resp, err := http.Get("http://" + lis.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("http get error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "http" {
+		t.Errorf("http body = %q, want %q", body, "http")
+	}
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc dial error = %v", err)
+	}
+	defer conn.Close()
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("grpc health check error = %v", err)
+	}
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("grpc status = %v, want SERVING", res.GetStatus())
+	}
+
+	if err := s.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Serve() error = %v", err)
+	}
+}
@@ -4,144 +4,106 @@ import (
 	"context"
 	"fmt"
 	"go-test-grpc-http/cmd/go-test-grpc-http/config"
-	"go-test-grpc-http/internal/api/grpc"
-	"go-test-grpc-http/internal/api/http"
-	"sync"
+	"go-test-grpc-http/internal/health"
+	"go-test-grpc-http/internal/lifecycle"
+	"go-test-grpc-http/internal/tracing"
 
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
 type app struct {
-	config     *config.Config
-	dbConn     *sqlx.DB
-	logger     *zap.Logger
-	httpServer http.Server
+	config *config.Config
+	logger *zap.Logger
+	// logLevel уровень логирования, меняется при перечитывании конфигурации
+	logLevel zap.AtomicLevel
+	// health проверки готовности, переводятся в NOT_SERVING при остановке
+	health *health.Registry
+	// lifecycle запускает и останавливает компоненты приложения
+	lifecycle *lifecycle.Manager
+	// done закрывается, когда приложение должно быть остановлено
+	done      <-chan struct{}
+	cancelApp context.CancelFunc
 }
 
-func NewApp(cfg *config.Config, logger *zap.Logger) *app {
+func NewApp(cfg *config.Config, logger *zap.Logger, logLevel zap.AtomicLevel) *app {
 	return &app{
-		config: cfg,
-		logger: logger,
+		config:    cfg,
+		logger:    logger,
+		logLevel:  logLevel,
+		health:    health.NewRegistry(),
+		lifecycle: lifecycle.NewManager(cfg.ShutdownTimeout, logger),
 	}
 }
 
-func (a *app) Start(ctx context.Context) {
+// Start собирает и запускает компоненты приложения. Приложение следует остановить через GracefulShutdown,
+// когда закроется канал Done: по отмене ctx, при ошибке запуска или при остановке любого из серверов.
+func (a *app) Start(ctx context.Context) error {
 	appCtx, cancelApp := context.WithCancel(ctx)
-	logger := a.logger
-	defer func() {
-		if e := recover(); e != nil {
-			logger.Panic("application shutdown", zap.Error(fmt.Errorf("%s", e)))
-			cancelApp()
-		}
-	}()
-	// Инициализируем БД
-	dbConn, err := a.initDb(appCtx,
-		a.config.DB.Host,
-		a.config.DB.Port,
-		a.config.DB.Name,
-		a.config.DB.Username,
-		a.config.DB.Password,
-		a.config.DB.SSLMode,
-	)
+	a.done = appCtx.Done()
+	a.cancelApp = cancelApp
+
+	err := a.build(appCtx)
 	if err != nil {
-		logger.Fatal("init db error", zap.Error(err))
+		cancelApp()
+		return fmt.Errorf("can't build application: %w", err)
 	}
-	a.dbConn = dbConn
-
-	// Запуск миграций
-	err = a.startMigrate(appCtx, migrationsPath, a.config.DB.Name, a.dbConn)
+	err = a.lifecycle.Start(appCtx)
 	if err != nil {
-		logger.Error("db migration error", zap.Error(err))
+		cancelApp()
+		return fmt.Errorf("can't start application: %w", err)
 	}
 
-	wg := &sync.WaitGroup{}
-	// Старт HTTP-сервера
-	wg.Add(1)
+	// Останавливаем приложение, если любой из серверов завершил работу
 	go func() {
-		defer func() {
-			if e := recover(); e != nil {
-				logger.Panic("http start panic", zap.Error(fmt.Errorf("%s", e)))
-			}
-			wg.Done()
-		}()
-		addr := fmt.Sprintf("%s:%d", a.config.HttpServer.Host, a.config.HttpServer.Port)
-		a.httpServer = http.NewServer(addr, a.dbConn, logger)
-		if a.httpServer == nil {
+		select {
+		case <-a.lifecycle.Done():
 			cancelApp()
-			logger.Fatal("can't create http server")
-			return
-		}
-		err := a.httpServer.Run(appCtx)
-		// Отменяем контекст, если HTTP-сервер завершил работу
-		cancelApp()
-		if err != nil {
-			logger.Error("can't start http server", zap.Error(err))
-			return
+		case <-appCtx.Done():
 		}
 	}()
 
-	// Старт GRPC-сервера
-	wg.Add(1)
-	go func() {
-		defer func() {
-			if e := recover(); e != nil {
-				logger.Panic("grpc start panic", zap.Error(fmt.Errorf("%s", e)))
-			}
-			wg.Done()
-		}()
-
-		addr := fmt.Sprintf("%s:%d", a.config.GrpcServer.Host, a.config.GrpcServer.Port)
-		grpcServer := grpc.NewServer(addr, dbConn, logger)
-		if grpcServer == nil {
-			cancelApp()
-			logger.Fatal("can't create grpc server")
-			return
-		}
+	return nil
+}
 
-		err := grpcServer.Run(ctx)
-		// Отменяем контекст, если GRPC-сервер завершил работу
-		cancelApp()
-		if err != nil {
-			if err != nil {
-				logger.Error("can't start grpc server", zap.Error(err))
-				return
-			}
-		}
-	}()
+// Done закрывается, когда приложение должно быть остановлено
+func (a *app) Done() <-chan struct{} {
+	return a.done
 }
 
-// GracefulShutdown graceful shutdown приложения
+// GracefulShutdown graceful shutdown приложения. Переводит готовность в NOT_SERVING,
+// параллельно дожидается завершения запросов HTTP и gRPC, останавливает фоновые задачи и закрывает ресурсы.
+// Каждый этап ограничен ShutdownTimeout, срок отсчитывается от ctx.
 func (a *app) GracefulShutdown(ctx context.Context) error {
-	err := a.httpServer.Shutdown(ctx)
-	if err != nil {
-		return fmt.Errorf("can't shutdown http-server: %w", err)
+	// Сообщаем балансировщикам, что новые запросы направлять не нужно
+	a.health.Shutdown()
+	if a.cancelApp != nil {
+		a.cancelApp()
 	}
-	err = a.dbConn.Close()
-	if err != nil {
-		return fmt.Errorf("can't shutdown db: %w", err)
+
+	report := a.lifecycle.Shutdown(ctx)
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("can't shutdown application: %w", err)
 	}
 	return nil
 }
 
-// initDb инициализация базы данных
-func (a *app) initDb(
-	ctx context.Context,
-	host string,
-	port int,
-	dbName string,
-	user string,
-	password string,
-	sslmode string,
-) (*sqlx.DB, error) {
-	db, err := sqlx.ConnectContext(
-		ctx,
+// OpenDB подключается к PostgreSQL с параметрами cfg.DB
+func OpenDB(ctx context.Context, cfg *config.Config) (*sqlx.DB, error) {
+	sqlDB, err := tracing.OpenDB(
 		"postgres",
-		fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s", host, port, user, password, dbName, sslmode),
+		fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			cfg.DB.Host, cfg.DB.Port, cfg.DB.Username, cfg.DB.Password, cfg.DB.Name, cfg.DB.SSLMode),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	db := sqlx.NewDb(sqlDB, "postgres")
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
 	return db, nil
 }
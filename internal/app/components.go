@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/api/connect"
+	"go-test-grpc-http/internal/api/gateway"
+	"go-test-grpc-http/internal/api/grpc"
+	"go-test-grpc-http/internal/api/http"
+	"go-test-grpc-http/internal/api/multiplex"
+	"go-test-grpc-http/internal/db"
+	"go-test-grpc-http/internal/lifecycle"
+	"go-test-grpc-http/internal/ratelimit"
+	"go-test-grpc-http/internal/reload"
+	"go-test-grpc-http/internal/repository"
+	"go-test-grpc-http/internal/tracing"
+	"go-test-grpc-http/internal/usecase"
+)
+
+// build единая точка сборки приложения. Компоненты создаются в порядке зависимостей
+// и добавляются в lifecycle в том же порядке, поэтому останавливаются в обратном: хранилище закрывается последним.
+// Оба транспорта получают одни и те же экземпляры сценариев использования.
+func (a *app) build(ctx context.Context) error {
+	cfg := a.config
+	logger := a.logger
+
+	// Хранилище пользователей
+	newSource, ok := userSources[cfg.Storage]
+	if !ok {
+		return fmt.Errorf("unknown storage %q", cfg.Storage)
+	}
+	source, err := newSource(a, ctx)
+	if err != nil {
+		return fmt.Errorf("can't init %s storage: %w", cfg.Storage, err)
+	}
+
+	// Трассировка
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName:    cfg.AppInfo.Name,
+		ServiceVersion: cfg.AppInfo.Version,
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.Endpoint,
+		File:           cfg.Tracing.File,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("can't init tracing: %w", err)
+	}
+	a.lifecycle.AddResource(lifecycle.Component{Name: "tracing", Stop: shutdownTracing})
+
+	// Сценарии использования
+	userRepository := repository.Trace(repository.NewUserRepository(db.Instrument(source)))
+	userInteractor := usecase.Trace(usecase.NewUserInteractor(userRepository))
+	exportJobs := usecase.NewExportJobs(userInteractor, cfg.Export.Dir)
+	a.lifecycle.AddWorker(lifecycle.Component{Name: "export jobs", Stop: exportJobs.Stop})
+
+	// Перечитывание конфигурации без перезапуска
+	watcher := reload.NewWatcher(cfg.ConfigFile, a.logLevel, logger)
+	a.lifecycle.AddWorker(lifecycle.Component{Name: "config watcher", Start: watcher.Start, Stop: watcher.Stop})
+
+	// Общее для HTTP и gRPC ограничение частоты запросов
+	limiter := ratelimit.NewLimiter(func() (float64, int) {
+		current, err := config.GetAppConfig()
+		if err != nil {
+			return 0, 0
+		}
+		return current.RateLimit.RPS, current.RateLimit.Burst
+	})
+
+	// gRPC-сервер
+	grpcAddr := fmt.Sprintf("%s:%d", cfg.GrpcServer.Host, cfg.GrpcServer.Port)
+	grpcServer := grpc.NewServer(grpcAddr, userInteractor, a.health, limiter, logger)
+
+	// HTTP/JSON-шлюз и Connect обращаются к gRPC-серверу внутри процесса
+	grpcConn, err := grpcServer.DialInProcess(ctx)
+	if err != nil {
+		return fmt.Errorf("can't connect gateway to grpc server: %w", err)
+	}
+	a.lifecycle.AddResource(lifecycle.Component{
+		Name: "grpc in-process client",
+		Stop: func(context.Context) error {
+			return grpcConn.Close()
+		},
+	})
+	gatewayHandler, err := gateway.NewHandler(ctx, grpcConn)
+	if err != nil {
+		return fmt.Errorf("can't create gateway: %w", err)
+	}
+	grpcHandlers := http.GRPCHandlers{
+		Gateway: gatewayHandler,
+		Connect: connect.NewHandler(grpcConn),
+	}
+
+	// gRPC, gRPC-Web и HTTP на одном порту
+	httpAddr := fmt.Sprintf("%s:%d", cfg.HttpServer.Host, cfg.HttpServer.Port)
+	if cfg.SinglePort {
+		handler, err := http.NewHandler(userInteractor, exportJobs, a.health, limiter, grpcHandlers, logger)
+		if err != nil {
+			return fmt.Errorf("can't create http handler: %w", err)
+		}
+		server := multiplex.NewServer(httpAddr, grpcServer, handler, logger)
+		a.lifecycle.AddServer(lifecycle.Component{Name: "multiplex", Start: server.Run, Stop: server.Shutdown})
+		return nil
+	}
+
+	httpServer := http.NewServer(httpAddr, userInteractor, exportJobs, a.health, limiter, grpcHandlers, logger)
+	if httpServer == nil {
+		return fmt.Errorf("can't create http server")
+	}
+	a.lifecycle.AddServer(lifecycle.Component{Name: "http", Start: httpServer.Run, Stop: httpServer.Shutdown})
+	a.lifecycle.AddServer(lifecycle.Component{Name: "grpc", Start: grpcServer.Run, Stop: grpcServer.Shutdown})
+
+	return nil
+}
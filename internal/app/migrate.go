@@ -2,43 +2,275 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"go-test-grpc-http/internal/health"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
 )
 
-const migrationsPath = "migrations"
+const (
+	migrationsPath   = "migrations"
+	migrationsSchema = "public"
+)
 
 //go:embed  migrations/*.sql
 var fs embed.FS
 
-func (a *app) startMigrate(ctx context.Context, migratePath string, dbName string, db *sqlx.DB) error {
-	err := db.PingContext(ctx)
+// Migrator применяет и откатывает встроенные миграции схемы БД
+type Migrator struct {
+	instance *migrate.Migrate
+	conn     *sql.Conn
+	// lockID ключ advisory lock, тот же, что берет golang-migrate на время каждой операции
+	lockID string
+}
+
+// NewMigrator создает Migrator на отдельном соединении из db. Соединение возвращается в пул при Close.
+func NewMigrator(ctx context.Context, db *sqlx.DB, dbName string) (*Migrator, error) {
+	conn, err := db.Conn(ctx)
 	if err != nil {
-		return fmt.Errorf("db connection not alive: %w", err)
+		return nil, fmt.Errorf("db connection not alive: %w", err)
 	}
-	driver, err := postgres.WithInstance(db.DB, &postgres.Config{
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{
 		DatabaseName: dbName,
-		SchemaName:   "public",
+		SchemaName:   migrationsSchema,
 	})
 	if err != nil {
-		return fmt.Errorf("db migration database driver error: %w", err)
+		conn.Close()
+		return nil, fmt.Errorf("db migration database driver error: %w", err)
 	}
-	source, err := iofs.New(fs, migratePath)
+	source, err := iofs.New(fs, migrationsPath)
 	if err != nil {
-		return fmt.Errorf("db migration source driver error: %w", err)
+		driver.Close()
+		return nil, fmt.Errorf("db migration source driver error: %w", err)
 	}
 	instance, err := migrate.NewWithInstance("fs", source, dbName, driver)
 	if err != nil {
-		return fmt.Errorf("db migration instance error: %w", err)
+		driver.Close()
+		return nil, fmt.Errorf("db migration instance error: %w", err)
+	}
+	lockID, err := database.GenerateAdvisoryLockId(dbName, migrationsSchema, postgres.DefaultMigrationsTable)
+	if err != nil {
+		instance.Close()
+		return nil, fmt.Errorf("db migration lock id error: %w", err)
 	}
-	if err := instance.Up(); err != nil && err != migrate.ErrNoChange {
+
+	return &Migrator{
+		instance: instance,
+		conn:     conn,
+		lockID:   lockID,
+	}, nil
+}
+
+// Lock берет advisory lock миграций, чтобы проверка и применение схемы несколькими репликами
+// выполнялись по очереди. Ожидание ограничено ctx. Блокировка принадлежит соединению Migrator,
+// поэтому golang-migrate повторно берет ее внутри операций без ожидания.
+func (m *Migrator) Lock(ctx context.Context) error {
+	_, err := m.conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockID)
+	if ctx.Err() != nil {
+		return fmt.Errorf("db migration lock is held by another process: %w", ctx.Err())
+	}
+	if err != nil {
+		return fmt.Errorf("db migration lock error: %w", err)
+	}
+	return nil
+}
+
+// Unlock освобождает advisory lock, взятый Lock
+func (m *Migrator) Unlock() error {
+	_, err := m.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", m.lockID)
+	if err != nil {
+		return fmt.Errorf("db migration unlock error: %w", err)
+	}
+	return nil
+}
+
+// Up применяет все непримененные миграции
+func (m *Migrator) Up() error {
+	if err := m.instance.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return fmt.Errorf("db migration up error: %w", err)
 	}
+	return nil
+}
+
+// Down откатывает steps последних примененных миграций
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("db migration down error: steps must be positive, got %d", steps)
+	}
+	if err := m.instance.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("db migration down error: %w", err)
+	}
+	return nil
+}
+
+// Goto применяет или откатывает миграции до версии version
+func (m *Migrator) Goto(version uint) error {
+	if err := m.instance.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("db migration goto %d error: %w", version, err)
+	}
+	return nil
+}
+
+// Version возвращает текущую версию схемы и признак незавершенной миграции.
+// Для пустой БД возвращает версию 0.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.instance.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("db migration version error: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Force записывает версию схемы без выполнения миграций и снимает признак dirty.
+// Используется после ручного исправления неудавшейся миграции, версия -1 означает пустую схему.
+func (m *Migrator) Force(version int) error {
+	if err := m.instance.Force(version); err != nil {
+		return fmt.Errorf("db migration force %d error: %w", version, err)
+	}
+	return nil
+}
+
+// Latest возвращает номер последней встроенной миграции
+func (m *Migrator) Latest() (uint, error) {
+	return latestMigration(migrationsPath)
+}
+
+// Stop просит завершить работу после текущей миграции
+func (m *Migrator) Stop() {
+	select {
+	case m.instance.GracefulStop <- true:
+	default:
+	}
+}
+
+// Close освобождает соединение с БД
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.instance.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// migrateOnStart запускает миграции при старте. С неудавшейся миграцией приложение не запускается,
+// если это не разрешено явно параметром AllowDirty.
+func (a *app) migrateOnStart(ctx context.Context, dbName string, db *sqlx.DB) error {
+	err := a.startMigrate(ctx, dbName, db)
+	if err != nil && !a.config.Migrate.AllowDirty {
+		return fmt.Errorf("db migration error: %w", err)
+	}
+	if err != nil {
+		a.logger.Error("db migration error, starting anyway", zap.Error(err))
+	}
 
 	return nil
 }
+
+// startMigrate под advisory lock проверяет состояние схемы, применяет миграции и сверяет версию с ожидаемой.
+// Ошибка означает, что приложению нельзя работать с этой схемой.
+func (a *app) startMigrate(ctx context.Context, dbName string, db *sqlx.DB) error {
+	cfg := a.config.Migrate
+	migrator, err := NewMigrator(ctx, db, dbName)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	// Реплики, запущенные одновременно, применяют миграции по очереди
+	lockCtx, cancelLock := context.WithTimeout(ctx, cfg.LockTimeout)
+	defer cancelLock()
+	if err := migrator.Lock(lockCtx); err != nil {
+		return err
+	}
+	defer migrator.Unlock()
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty: fix it manually and run migrate force", version)
+	}
+	latest, err := migrator.Latest()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cfg.Skip:
+		a.logger.Info("db migrations skipped", zap.Uint("version", version), zap.Uint("latest", latest))
+	case version > latest:
+		// Схему уже обновила более новая версия приложения
+		a.logger.Warn("db schema is newer than migrations", zap.Uint("version", version), zap.Uint("latest", latest))
+	default:
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+		if version, _, err = migrator.Version(); err != nil {
+			return err
+		}
+		a.logger.Info("db migrations applied", zap.Uint("version", version))
+	}
+
+	if cfg.ExpectedVersion != 0 && version != cfg.ExpectedVersion {
+		return fmt.Errorf("schema version %d, expected %d", version, cfg.ExpectedVersion)
+	}
+
+	return nil
+}
+
+// latestMigration возвращает номер последней встроенной миграции
+func latestMigration(migratePath string) (uint, error) {
+	source, err := iofs.New(fs, migratePath)
+	if err != nil {
+		return 0, fmt.Errorf("db migration source driver error: %w", err)
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, fmt.Errorf("can't read first migration: %w", err)
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("can't read migration after %d: %w", version, err)
+		}
+		version = next
+	}
+}
+
+// migrationsCheck проверяет, что схема БД не dirty и применена не ниже версии want.
+// Более новая схема допустима: ее могла обновить следующая версия приложения при постепенном развертывании.
+func migrationsCheck(db *sqlx.DB, want uint) health.CheckFunc {
+	return func(ctx context.Context) error {
+		var state struct {
+			Version uint `db:"version"`
+			Dirty   bool `db:"dirty"`
+		}
+		err := db.GetContext(ctx, &state, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+		if err != nil {
+			return fmt.Errorf("can't get migration version: %w", err)
+		}
+		if state.Dirty {
+			return fmt.Errorf("migration %d is dirty", state.Version)
+		}
+		if state.Version < want {
+			return fmt.Errorf("migration version %d, expected at least %d", state.Version, want)
+		}
+
+		return nil
+	}
+}
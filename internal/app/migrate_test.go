@@ -0,0 +1,299 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// postgresDSNEnv строка подключения к отдельной БД для проверки миграций. Схема откатывается и применяется заново.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+const (
+	lockQuery    = "SELECT pg_advisory_lock($1)"
+	unlockQuery  = "SELECT pg_advisory_unlock($1)"
+	versionQuery = `SELECT version, dirty FROM "public"."schema_migrations" LIMIT 1`
+)
+
+// expectVersion ожидает чтение версии схемы
+func expectVersion(mock sqlmock.Sqlmock, version uint, dirty bool) {
+	mock.ExpectQuery(versionQuery).WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(version, dirty))
+}
+
+// expectSetVersion ожидает запись версии схемы
+func expectSetVersion(mock sqlmock.Sqlmock, version uint, dirty bool) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`TRUNCATE "public"."schema_migrations"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "public"."schema_migrations" (version, dirty) VALUES ($1, $2)`).
+		WithArgs(int(version), dirty).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func Test_app_migrateOnStart(t *testing.T) {
+	latest, err := latestMigration(migrationsPath)
+	if err != nil {
+		t.Fatalf("latestMigration() error = %v", err)
+	}
+	latestBody := readMigration(t, latest, "up")
+
+	tests := []struct {
+		name    string
+		migrate func(cfg *config.Config)
+		// expect ожидаемые запросы после взятия advisory lock
+		expect  func(mock sqlmock.Sqlmock)
+		wantErr string
+	}{
+		{
+			name: "schema is up to date",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest, false)
+				// Up без новых миграций
+				mock.ExpectExec(lockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectVersion(mock, latest, false)
+				mock.ExpectExec(unlockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectVersion(mock, latest, false)
+			},
+		},
+		{
+			name: "pending migration is applied",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest-1, false)
+				mock.ExpectExec(lockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectVersion(mock, latest-1, false)
+				expectSetVersion(mock, latest, true)
+				mock.ExpectExec(string(latestBody)).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectSetVersion(mock, latest, false)
+				mock.ExpectExec(unlockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectVersion(mock, latest, false)
+			},
+		},
+		{
+			name: "dirty schema stops start",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest, true)
+			},
+			wantErr: "is dirty",
+		},
+		{
+			name: "allow dirty starts anyway",
+			migrate: func(cfg *config.Config) {
+				cfg.Migrate.AllowDirty = true
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest, true)
+			},
+		},
+		{
+			name: "newer schema is not migrated",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest+1, false)
+			},
+		},
+		{
+			name: "skip leaves old schema",
+			migrate: func(cfg *config.Config) {
+				cfg.Migrate.Skip = true
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest-1, false)
+			},
+		},
+		{
+			name: "expected version mismatch stops start",
+			migrate: func(cfg *config.Config) {
+				cfg.Migrate.Skip = true
+				cfg.Migrate.ExpectedVersion = latest
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest-1, false)
+			},
+			wantErr: fmt.Sprintf("expected %d", latest),
+		},
+		{
+			name: "expected version matches after migration",
+			migrate: func(cfg *config.Config) {
+				cfg.Migrate.ExpectedVersion = latest
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest, false)
+				mock.ExpectExec(lockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectVersion(mock, latest, false)
+				mock.ExpectExec(unlockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectVersion(mock, latest, false)
+			},
+		},
+		{
+			name: "expected version mismatch is ignored with allow dirty",
+			migrate: func(cfg *config.Config) {
+				cfg.Migrate.Skip = true
+				cfg.Migrate.AllowDirty = true
+				cfg.Migrate.ExpectedVersion = latest
+			},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, latest-1, false)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Migrate.LockTimeout = time.Second
+			if tt.migrate != nil {
+				tt.migrate(cfg)
+			}
+			a := &app{config: cfg, logger: zap.NewNop()}
+
+			conn, mock := newMigrateMock(t)
+			mock.ExpectExec(lockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+			tt.expect(mock)
+			mock.ExpectExec(unlockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+			err := a.migrateOnStart(context.Background(), "db", conn)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("migrateOnStart() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("migrateOnStart() error = %v, want %q", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("migrateOnStart() unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func Test_app_startMigrate_lockTimeout(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Migrate.LockTimeout = 50 * time.Millisecond
+	a := &app{config: cfg, logger: zap.NewNop()}
+
+	conn, mock := newMigrateMock(t)
+	// Блокировку держит другая реплика
+	mock.ExpectExec(lockQuery).WithArgs(sqlmock.AnyArg()).WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := a.startMigrate(context.Background(), "db", conn)
+	if err == nil || !strings.Contains(err.Error(), "held by another process") {
+		t.Fatalf("startMigrate() error = %v, want lock timeout", err)
+	}
+}
+
+func Test_migrations_haveDown(t *testing.T) {
+	names := migrationFiles(t)
+	for name := range names {
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		if down := strings.TrimSuffix(name, ".up.sql") + ".down.sql"; !names[down] {
+			t.Errorf("migration %s has no down migration %s", name, down)
+		}
+	}
+}
+
+func TestMigrator_roundTrip(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	ctx := context.Background()
+	conn, err := sqlx.ConnectContext(ctx, "postgres", dsn)
+	if err != nil {
+		t.Fatalf("can't connect to postgres: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	var dbName string
+	if err := conn.GetContext(ctx, &dbName, "SELECT current_database()"); err != nil {
+		t.Fatalf("can't get database name: %v", err)
+	}
+	migrator, err := NewMigrator(ctx, conn, dbName)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	defer migrator.Close()
+
+	latest, err := migrator.Latest()
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	checkVersion := func(want uint) {
+		t.Helper()
+		version, dirty, err := migrator.Version()
+		if err != nil || dirty || version != want {
+			t.Fatalf("Version() = %d, dirty %v, error %v, want %d", version, dirty, err, want)
+		}
+	}
+
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	checkVersion(latest)
+	if err := migrator.Down(int(latest)); err != nil {
+		t.Fatalf("Down(%d) error = %v", latest, err)
+	}
+	checkVersion(0)
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Up() after down error = %v", err)
+	}
+	checkVersion(latest)
+}
+
+// newMigrateMock возвращает БД sqlmock, в которой таблица версий уже создана.
+// Запросы, которые golang-migrate выполняет при создании Migrator, ожидаются сразу.
+func newMigrateMock(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mock.ExpectExec(lockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COUNT(1) FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2 LIMIT 1").
+		WithArgs(migrationsSchema, "schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(unlockQuery).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+// migrationFiles возвращает имена встроенных файлов миграций
+func migrationFiles(t *testing.T) map[string]bool {
+	t.Helper()
+	entries, err := fs.ReadDir(migrationsPath)
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("can't list migrations: %v", err)
+	}
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	return names
+}
+
+// readMigration возвращает текст миграции version в направлении direction (up или down)
+func readMigration(t *testing.T, version uint, direction string) []byte {
+	t.Helper()
+	prefix, suffix := fmt.Sprintf("%06d_", version), "."+direction+".sql"
+	for name := range migrationFiles(t) {
+		if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, suffix) {
+			body, err := fs.ReadFile(path.Join(migrationsPath, name))
+			if err != nil {
+				t.Fatalf("can't read migration %s: %v", name, err)
+			}
+			return body
+		}
+	}
+	t.Fatalf("migration %d %s not found", version, direction)
+	return nil
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_history;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS updated_at;
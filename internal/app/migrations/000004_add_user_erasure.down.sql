@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS user_history_actor_id_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
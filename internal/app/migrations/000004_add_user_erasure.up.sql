@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS user_history_actor_id_idx ON user_history (actor_id, id);
@@ -0,0 +1,5 @@
+-- Приводим существующие адреса к каноничному виду. Если после этого останутся дубликаты,
+-- создание индекса завершится ошибкой и их нужно будет разрешить вручную.
+UPDATE users SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_idx ON users (lower(email));
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/db"
+	"go-test-grpc-http/internal/lifecycle"
+	"go-test-grpc-http/internal/metrics"
+
+	"go.uber.org/zap"
+)
+
+// Хранилища пользователей
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+// userSources реализации хранилища пользователей, выбираемые параметром STORAGE.
+// Фабрика сама добавляет в lifecycle свои ресурсы и проверки готовности.
+var userSources = map[string]func(a *app, ctx context.Context) (db.UserSource, error){
+	StoragePostgres: (*app).postgresSource,
+	StorageMemory:   (*app).memorySource,
+}
+
+// postgresSource подключается к PostgreSQL и применяет миграции
+func (a *app) postgresSource(ctx context.Context) (db.UserSource, error) {
+	cfg := a.config.DB
+	dbConn, err := OpenDB(ctx, a.config)
+	if err != nil {
+		return nil, fmt.Errorf("init db error: %w", err)
+	}
+	a.lifecycle.AddResource(lifecycle.Component{
+		Name: "db",
+		Stop: func(context.Context) error {
+			return dbConn.Close()
+		},
+	})
+	err = metrics.RegisterDBStats(dbConn, cfg.Name)
+	if err != nil {
+		a.logger.Error("can't register db metrics", zap.Error(err))
+	}
+
+	err = a.migrateOnStart(ctx, cfg.Name, dbConn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверки готовности подсистем
+	a.health.Register("db", dbConn.PingContext)
+	expected := a.config.Migrate.ExpectedVersion
+	if expected == 0 {
+		expected, err = latestMigration(migrationsPath)
+		if err != nil {
+			return nil, fmt.Errorf("can't read migrations: %w", err)
+		}
+	}
+	a.health.Register("migrations", migrationsCheck(dbConn, expected))
+
+	return db.NewSource(dbConn), nil
+}
+
+// memorySource хранит пользователей в памяти процесса, данные теряются при остановке.
+// Миграции и проверки БД не нужны.
+func (a *app) memorySource(context.Context) (db.UserSource, error) {
+	a.logger.Warn("users are stored in memory and will be lost on shutdown")
+	return db.NewMemorySource(), nil
+}
@@ -0,0 +1,547 @@
+package db_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/app"
+	"go-test-grpc-http/internal/db"
+	"go-test-grpc-http/internal/entity"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// postgresDSNEnv строка подключения к отдельной БД для проверки source. Таблицы users и user_history очищаются.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+func TestMemorySource_Conformance(t *testing.T) {
+	testUserSource(t, func(t *testing.T) db.UserSource {
+		return db.NewMemorySource()
+	})
+
+}
+
+func TestPostgresSource_Conformance(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	ctx := context.Background()
+	conn, err := sqlx.ConnectContext(ctx, "postgres", dsn)
+	if err != nil {
+		t.Fatalf("can't connect to postgres: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	var dbName string
+	if err := conn.GetContext(ctx, &dbName, "SELECT current_database()"); err != nil {
+		t.Fatalf("can't get database name: %v", err)
+	}
+	migrator, err := app.NewMigrator(ctx, conn, dbName)
+	if err != nil {
+		t.Fatalf("can't create migrator: %v", err)
+	}
+	err = migrator.Up()
+	migrator.Close()
+	if err != nil {
+		t.Fatalf("can't apply migrations: %v", err)
+	}
+
+	testUserSource(t, func(t *testing.T) db.UserSource {
+		if _, err := conn.ExecContext(ctx, "TRUNCATE users, user_history RESTART IDENTITY"); err != nil {
+			t.Fatalf("can't truncate tables: %v", err)
+		}
+		return db.NewSource(conn)
+	})
+}
+
+// testUserSource проверяет поведение, общее для всех реализаций db.UserSource.
+// newSource возвращает пустой источник для каждой проверки.
+func testUserSource(t *testing.T, newSource func(t *testing.T) db.UserSource) {
+	ctx := context.Background()
+	unknown := &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}
+
+	t.Run("create and get", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("doe@example.com"))
+
+		byID, err := s.GetUserById(ctx, id)
+		if err != nil {
+			t.Fatalf("GetUserById() error = %v", err)
+		}
+		if byID.ID != id.Id || byID.Email != "doe@example.com" || byID.FirstName != "John" || byID.Password != "qwerty1234" {
+			t.Errorf("GetUserById() = %+v", byID)
+		}
+		if byID.Role != entity.RoleUser || byID.CreatedAt.IsZero() || !byID.CreatedAt.Equal(byID.UpdatedAt) {
+			t.Errorf("GetUserById() role = %q, created_at = %v, updated_at = %v", byID.Role, byID.CreatedAt, byID.UpdatedAt)
+		}
+		if byID.ErasedAt != nil || byID.DisabledAt != nil {
+			t.Errorf("GetUserById() erased_at = %v, disabled_at = %v, want nil", byID.ErasedAt, byID.DisabledAt)
+		}
+
+		byEmail, err := s.GetUserByEmail(ctx, "DOE@example.com")
+		if err != nil || byEmail.ID != id.Id {
+			t.Errorf("GetUserByEmail() = %v, %v, want %s", byEmail, err, id)
+		}
+		gotID, err := s.GetUserIdByEmail(ctx, "Doe@Example.com")
+		if err != nil || gotID.Id != id.Id {
+			t.Errorf("GetUserIdByEmail() = %v, %v, want %s", gotID, err, id)
+		}
+	})
+
+	t.Run("role is set on create", func(t *testing.T) {
+		s := newSource(t)
+		user := newUser("admin@example.com")
+		user.Role = entity.RoleAdmin
+		id := mustCreate(t, s, user)
+
+		got, err := s.GetUserById(ctx, id)
+		if err != nil || got.Role != entity.RoleAdmin {
+			t.Errorf("GetUserById() = %v, %v, want role %q", got, err, entity.RoleAdmin)
+		}
+	})
+
+	t.Run("email is unique ignoring case", func(t *testing.T) {
+		s := newSource(t)
+		mustCreate(t, s, newUser("doe@example.com"))
+
+		_, err := s.CreateUser(ctx, newUser("DOE@example.com"))
+		if !errors.Is(err, entity.ErrEmailTaken) {
+			t.Errorf("CreateUser() error = %v, want %v", err, entity.ErrEmailTaken)
+		}
+
+		other := mustCreate(t, s, newUser("other@example.com"))
+		_, err = s.UpdateUser(ctx, other, newUser("Doe@example.com"))
+		if !errors.Is(err, entity.ErrEmailTaken) {
+			t.Errorf("UpdateUser() error = %v, want %v", err, entity.ErrEmailTaken)
+		}
+		// Пользователь может сменить регистр собственного email
+		_, err = s.UpdateUser(ctx, other, newUser("OTHER@example.com"))
+		if err != nil {
+			t.Errorf("UpdateUser() own email error = %v", err)
+		}
+	})
+
+	// Хранилище сравнивает email без учета регистра, как индекс users_email_lower_idx.
+	// Пробелы по краям убирает сценарий использования (entity.NormalizeEmail), хранилище их не трогает.
+	t.Run("email lookup ignores only case", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("Doe@Example.com"))
+
+		got, err := s.GetUserIdByEmail(ctx, "dOE@example.COM")
+		if err != nil || got.Id != id.Id {
+			t.Errorf("GetUserIdByEmail() = %v, %v, want %v", got, err, id)
+		}
+		_, err = s.GetUserIdByEmail(ctx, " doe@example.com ")
+		if !errors.Is(err, entity.ErrUserNotFound) {
+			t.Errorf("GetUserIdByEmail() with spaces error = %v, want %v", err, entity.ErrUserNotFound)
+		}
+	})
+
+	t.Run("unknown user is not found", func(t *testing.T) {
+		s := newSource(t)
+		mustCreate(t, s, newUser("doe@example.com"))
+
+		checks := map[string]error{}
+		_, checks["GetUserById"] = s.GetUserById(ctx, unknown)
+		_, checks["GetUserByEmail"] = s.GetUserByEmail(ctx, "nobody@example.com")
+		_, checks["GetUserIdByEmail"] = s.GetUserIdByEmail(ctx, "nobody@example.com")
+		_, checks["UpdateUser"] = s.UpdateUser(ctx, unknown, newUser("nobody@example.com"))
+		checks["DeleteUser"] = s.DeleteUser(ctx, unknown)
+		_, checks["SetUserRole"] = s.SetUserRole(ctx, unknown, entity.RoleAdmin)
+		_, checks["SetUserDisabled"] = s.SetUserDisabled(ctx, unknown, true)
+		_, checks["SetUserPassword"] = s.SetUserPassword(ctx, unknown, "secret")
+		checks["EraseUser"] = s.EraseUser(ctx, unknown)
+		for method, err := range checks {
+			if !errors.Is(err, entity.ErrUserNotFound) {
+				t.Errorf("%s() error = %v, want %v", method, err, entity.ErrUserNotFound)
+			}
+		}
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("doe@example.com"))
+
+		update := newUser("new@example.com")
+		update.Age = 31
+		updated, err := s.UpdateUser(ctx, id, update)
+		if err != nil {
+			t.Fatalf("UpdateUser() error = %v", err)
+		}
+		if updated.Email != "new@example.com" || updated.Age != 31 || updated.UpdatedAt.Before(updated.CreatedAt) {
+			t.Errorf("UpdateUser() = %+v", updated)
+		}
+		if _, err := s.GetUserByEmail(ctx, "doe@example.com"); !errors.Is(err, entity.ErrUserNotFound) {
+			t.Errorf("GetUserByEmail() old email error = %v, want %v", err, entity.ErrUserNotFound)
+		}
+
+		if err := s.DeleteUser(ctx, id); err != nil {
+			t.Fatalf("DeleteUser() error = %v", err)
+		}
+		if _, err := s.GetUserById(ctx, id); !errors.Is(err, entity.ErrUserNotFound) {
+			t.Errorf("GetUserById() after delete error = %v, want %v", err, entity.ErrUserNotFound)
+		}
+		// Email удаленного пользователя снова свободен
+		mustCreate(t, s, newUser("new@example.com"))
+	})
+
+	t.Run("role, block and password", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("doe@example.com"))
+
+		user, err := s.SetUserRole(ctx, id, entity.RoleAdmin)
+		if err != nil || user.Role != entity.RoleAdmin {
+			t.Errorf("SetUserRole() = %v, %v", user, err)
+		}
+
+		disabled, err := s.SetUserDisabled(ctx, id, true)
+		if err != nil || disabled.DisabledAt == nil {
+			t.Fatalf("SetUserDisabled(true) = %v, %v", disabled, err)
+		}
+		again, err := s.SetUserDisabled(ctx, id, true)
+		if err != nil || again.DisabledAt == nil || !again.DisabledAt.Equal(*disabled.DisabledAt) {
+			t.Errorf("SetUserDisabled(true) again = %v, %v, want disabled_at %v", again, err, disabled.DisabledAt)
+		}
+		enabled, err := s.SetUserDisabled(ctx, id, false)
+		if err != nil || enabled.DisabledAt != nil {
+			t.Errorf("SetUserDisabled(false) = %v, %v", enabled, err)
+		}
+
+		user, err = s.SetUserPassword(ctx, id, "secret")
+		if err != nil || user.Password != "secret" || user.Role != entity.RoleAdmin {
+			t.Errorf("SetUserPassword() = %v, %v", user, err)
+		}
+	})
+
+	t.Run("history is ordered from newest and paged by cursor", func(t *testing.T) {
+		s := newSource(t)
+		actor := mustCreate(t, s, newUser("admin@example.com"))
+		id := mustCreate(t, s, newUser("doe@example.com"))
+		actorCtx := entity.ContextWithActor(ctx, actor)
+		if _, err := s.SetUserRole(actorCtx, id, entity.RoleAdmin); err != nil {
+			t.Fatalf("SetUserRole() error = %v", err)
+		}
+		if err := s.DeleteUser(actorCtx, id); err != nil {
+			t.Fatalf("DeleteUser() error = %v", err)
+		}
+
+		page, err := s.GetUserHistory(ctx, id, 0, 2)
+		if err != nil {
+			t.Fatalf("GetUserHistory() error = %v", err)
+		}
+		if got := historyActions(page); got != "delete,update" {
+			t.Errorf("GetUserHistory() actions = %s, want delete,update", got)
+		}
+		if page[1].Before == nil || page[1].After == nil || page[0].After != nil {
+			t.Errorf("GetUserHistory() snapshots before = %s, after = %s", page[1].Before, page[0].After)
+		}
+		if !page[0].ActorID.Valid || page[0].ActorID.UUID != actor.Id {
+			t.Errorf("GetUserHistory() actor = %v, want %s", page[0].ActorID, actor)
+		}
+
+		rest, err := s.GetUserHistory(ctx, id, page[1].ID, 10)
+		if err != nil {
+			t.Fatalf("GetUserHistory() next page error = %v", err)
+		}
+		if got := historyActions(rest); got != "create" {
+			t.Errorf("GetUserHistory() next page actions = %s, want create", got)
+		}
+
+		audit, err := s.GetUserAudit(ctx, actor, 0, 10)
+		if err != nil {
+			t.Fatalf("GetUserAudit() error = %v", err)
+		}
+		if got := historyActions(audit); got != "delete,update" {
+			t.Errorf("GetUserAudit() actions = %s, want delete,update", got)
+		}
+		if audit[0].Before != nil || audit[0].After != nil || audit[0].UserID != id.Id {
+			t.Errorf("GetUserAudit() = %+v, want entry without snapshots", audit[0])
+		}
+	})
+
+	t.Run("erase removes personal data", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("doe@example.com"))
+		if _, err := s.SetUserRole(ctx, id, entity.RoleAdmin); err != nil {
+			t.Fatalf("SetUserRole() error = %v", err)
+		}
+
+		if err := s.EraseUser(ctx, id); err != nil {
+			t.Fatalf("EraseUser() error = %v", err)
+		}
+		user, err := s.GetUserById(ctx, id)
+		if err != nil {
+			t.Fatalf("GetUserById() error = %v", err)
+		}
+		if user.ErasedAt == nil || user.Email != entity.ErasedEmail(id) || user.FirstName != "" || user.Password != "" || user.Role != entity.RoleAdmin {
+			t.Errorf("GetUserById() after erase = %+v", user)
+		}
+
+		history, err := s.GetUserHistory(ctx, id, 0, 10)
+		if err != nil {
+			t.Fatalf("GetUserHistory() error = %v", err)
+		}
+		if got := historyActions(history); got != "erase,update,create" {
+			t.Errorf("GetUserHistory() actions = %s, want erase,update,create", got)
+		}
+		for _, entry := range history {
+			for _, data := range [][]byte{entry.Before, entry.After} {
+				if snapshot := unmarshalSnapshot(t, data); snapshot != nil && (snapshot.Email == "doe@example.com" || snapshot.FirstName != "") {
+					t.Errorf("history %d keeps personal data: %s", entry.ID, data)
+				}
+			}
+		}
+
+		// Повторное обезличивание ничего не меняет
+		if err := s.EraseUser(ctx, id); err != nil {
+			t.Errorf("EraseUser() again error = %v", err)
+		}
+		if again, _ := s.GetUserHistory(ctx, id, 0, 10); len(again) != len(history) {
+			t.Errorf("EraseUser() again added history: %d entries, want %d", len(again), len(history))
+		}
+	})
+
+	t.Run("erase of deleted user clears history", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("doe@example.com"))
+		if err := s.DeleteUser(ctx, id); err != nil {
+			t.Fatalf("DeleteUser() error = %v", err)
+		}
+
+		if err := s.EraseUser(ctx, id); err != nil {
+			t.Fatalf("EraseUser() error = %v", err)
+		}
+		history, err := s.GetUserHistory(ctx, id, 0, 10)
+		if err != nil {
+			t.Fatalf("GetUserHistory() error = %v", err)
+		}
+		if got := historyActions(history); got != "erase,delete,create" {
+			t.Errorf("GetUserHistory() actions = %s, want erase,delete,create", got)
+		}
+		if snapshot := unmarshalSnapshot(t, history[1].Before); snapshot.Email != "" {
+			t.Errorf("history keeps email %q", snapshot.Email)
+		}
+	})
+
+	t.Run("export filters and orders by id", func(t *testing.T) {
+		s := newSource(t)
+		for i := 0; i < 5; i++ {
+			user := newUser(fmt.Sprintf("user%d@example.com", i))
+			user.Age = 20 + i
+			mustCreate(t, s, user)
+		}
+		mustCreate(t, s, newUser("other@test.org"))
+
+		minAge := 21
+		var exported []*entity.UserDB
+		err := s.ExportUsers(ctx, &entity.UserFilter{Email: "EXAMPLE", MinAge: &minAge}, func(user *entity.UserDB) error {
+			exported = append(exported, user)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ExportUsers() error = %v", err)
+		}
+		if len(exported) != 4 {
+			t.Fatalf("ExportUsers() exported %d users, want 4", len(exported))
+		}
+		for i := 1; i < len(exported); i++ {
+			if exported[i-1].ID.String() >= exported[i].ID.String() {
+				t.Errorf("ExportUsers() order: %s before %s", exported[i-1].ID, exported[i].ID)
+			}
+		}
+
+		stop := errors.New("stop")
+		err = s.ExportUsers(ctx, &entity.UserFilter{}, func(*entity.UserDB) error {
+			return stop
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("ExportUsers() error = %v, want %v", err, stop)
+		}
+	})
+
+	t.Run("import modes", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			opts        *entity.UserImportOptions
+			wantStatus  []entity.ImportStatus
+			wantCommit  bool
+			wantCreated int
+		}{
+			{
+				name:        "best-effort skips duplicates",
+				opts:        &entity.UserImportOptions{Mode: entity.ImportModeBestEffort},
+				wantStatus:  []entity.ImportStatus{entity.ImportStatusCreated, entity.ImportStatusFailed, entity.ImportStatusCreated},
+				wantCommit:  true,
+				wantCreated: 2,
+			},
+			{
+				name:       "all-or-nothing rolls back",
+				opts:       &entity.UserImportOptions{Mode: entity.ImportModeAllOrNothing},
+				wantStatus: []entity.ImportStatus{entity.ImportStatusRolledBack, entity.ImportStatusFailed, entity.ImportStatusRolledBack},
+			},
+			{
+				name:       "dry-run saves nothing",
+				opts:       &entity.UserImportOptions{Mode: entity.ImportModeBestEffort, DryRun: true},
+				wantStatus: []entity.ImportStatus{entity.ImportStatusValid, entity.ImportStatusFailed, entity.ImportStatusValid},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				s := newSource(t)
+				mustCreate(t, s, newUser("taken@example.com"))
+
+				batches := [][]*entity.UserImportRow{
+					{{Line: 2, User: newUser("a@example.com")}, {Line: 3, User: newUser("TAKEN@example.com")}},
+					{{Line: 4, User: newUser("b@example.com")}},
+				}
+				report, err := s.ImportUsers(ctx, tt.opts, func() ([]*entity.UserImportRow, error) {
+					if len(batches) == 0 {
+						return nil, io.EOF
+					}
+					batch := batches[0]
+					batches = batches[1:]
+					return batch, nil
+				})
+				if err != nil {
+					t.Fatalf("ImportUsers() error = %v", err)
+				}
+
+				if report.Committed != tt.wantCommit || report.Total != 3 || report.Failed != 1 {
+					t.Errorf("ImportUsers() report = %+v", report)
+				}
+				for i, result := range report.Results {
+					if result.Status != tt.wantStatus[i] {
+						t.Errorf("ImportUsers() line %d status = %s, want %s", result.Line, result.Status, tt.wantStatus[i])
+					}
+				}
+				if report.Results[1].Error == "" {
+					t.Errorf("ImportUsers() failed line has no error")
+				}
+
+				created := 0
+				s.ExportUsers(ctx, &entity.UserFilter{}, func(*entity.UserDB) error {
+					created++
+					return nil
+				})
+				if created-1 != tt.wantCreated {
+					t.Errorf("ImportUsers() saved %d users, want %d", created-1, tt.wantCreated)
+				}
+			})
+		}
+	})
+
+	t.Run("reading all-or-nothing import does not block reads", func(t *testing.T) {
+		s := newSource(t)
+		id := mustCreate(t, s, newUser("taken@example.com"))
+
+		read := false
+		done := make(chan error, 1)
+		go func() {
+			_, err := s.ImportUsers(ctx, &entity.UserImportOptions{Mode: entity.ImportModeAllOrNothing}, func() ([]*entity.UserImportRow, error) {
+				if read {
+					return nil, io.EOF
+				}
+				read = true
+				// Разбор загрузки идет одновременно с остальными запросами
+				got, err := s.GetUserIdByEmail(ctx, "taken@example.com")
+				if err != nil || got.Id != id.Id {
+					return nil, fmt.Errorf("GetUserIdByEmail() = %v, %v", got, err)
+				}
+				return []*entity.UserImportRow{{Line: 2, User: newUser("a@example.com")}}, nil
+			})
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("ImportUsers() error = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("ImportUsers() blocked reads while reading batches")
+		}
+	})
+
+	t.Run("concurrent creates keep email unique", func(t *testing.T) {
+		s := newSource(t)
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			created int
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.CreateUser(ctx, newUser("race@example.com"))
+				if err == nil {
+					mu.Lock()
+					created++
+					mu.Unlock()
+				} else if !errors.Is(err, entity.ErrEmailTaken) {
+					t.Errorf("CreateUser() error = %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if created != 1 {
+			t.Errorf("CreateUser() created %d users with one email, want 1", created)
+		}
+	})
+}
+
+func newUser(email string) *entity.UserCreate {
+	return &entity.UserCreate{
+		FirstName:  "John",
+		SecondName: "DoeD",
+		LastName:   "Doe",
+		Password:   "qwerty1234",
+		Age:        30,
+		Email:      email,
+		Phone:      "+1111111111",
+	}
+}
+
+func mustCreate(t *testing.T, s db.UserSource, user *entity.UserCreate) *entity.UserID {
+	t.Helper()
+	id, err := s.CreateUser(context.Background(), user)
+	if err != nil {
+		t.Fatalf("CreateUser(%s) error = %v", user.Email, err)
+	}
+	return id
+}
+
+func historyActions(entries []*entity.UserHistoryDB) string {
+	actions := ""
+	for i, entry := range entries {
+		if i > 0 {
+			actions += ","
+		}
+		actions += entry.Action
+	}
+	return actions
+}
+
+func unmarshalSnapshot(t *testing.T, data []byte) *entity.UserSnapshot {
+	t.Helper()
+	if data == nil {
+		return nil
+	}
+	var snapshot entity.UserSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatalf("can't unmarshal snapshot %s: %v", data, err)
+	}
+	return &snapshot
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"go-test-grpc-http/internal/entity"
+
+	"github.com/lib/pq"
+)
+
+const (
+	pgUniqueViolation = "23505"                 // Код ошибки postgres при нарушении уникальности
+	usersEmailIndex   = "users_email_lower_idx" // Уникальный индекс email без учета регистра
+)
+
+// translateError переводит известные ошибки бд в ошибки предметной области.
+// Все запросы одной строки в source выбирают пользователей, поэтому sql.ErrNoRows означает entity.ErrUserNotFound.
+func translateError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ErrUserNotFound
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation && pqErr.Constraint == usersEmailIndex {
+		return entity.ErrEmailTaken
+	}
+
+	return err
+}
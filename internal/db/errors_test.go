@@ -0,0 +1,54 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func Test_translateError(t *testing.T) {
+	otherErr := fmt.Errorf("connection refused")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "email unique violation",
+			err:  &pq.Error{Code: pgUniqueViolation, Constraint: usersEmailIndex},
+			want: entity.ErrEmailTaken,
+		},
+		{
+			name: "wrapped email unique violation",
+			err:  fmt.Errorf("can't exec query: %w", &pq.Error{Code: pgUniqueViolation, Constraint: usersEmailIndex}),
+			want: entity.ErrEmailTaken,
+		},
+		{
+			name: "other unique violation",
+			err:  &pq.Error{Code: pgUniqueViolation, Constraint: "users_pkey"},
+			want: nil,
+		},
+		{
+			name: "other error",
+			err:  otherErr,
+			want: otherErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := translateError(tt.err)
+			if tt.want == nil {
+				if got != tt.err {
+					t.Errorf("translateError() = %v, want %v", got, tt.err)
+				}
+				return
+			}
+			if !errors.Is(got, tt.want) {
+				t.Errorf("translateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+)
+
+// ExportUsers построчно передает в fn пользователей, подходящих под фильтр, в порядке id
+func (s *source) ExportUsers(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.UserDB) error) error {
+	where, args := buildUserFilter(filter)
+
+	rows, err := s.db.QueryxContext(ctx, "SELECT * FROM users"+where+" ORDER BY id", args...)
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userDB entity.UserDB
+		if err := rows.StructScan(&userDB); err != nil {
+			return fmt.Errorf("can't scan user: %w", err)
+		}
+		if err := fn(&userDB); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("can't read users: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func Test_source_ExportUsers(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.UserFilter
+	}
+	minAge := 18
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		setup   func(a args, f fields)
+		wantErr bool
+	}{
+		{
+			name: "success: ExportUsers source: all users",
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.UserFilter{},
+			},
+			want: []string{"doe@example.com", "roe@example.com"},
+			setup: func(a args, f fields) {
+				rows := sqlmock.NewRows([]string{"id", "email"}).
+					AddRow(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), "doe@example.com").
+					AddRow(uuid.MustParse("5a6e104d-9d7f-45ff-8de6-37993d709522"), "roe@example.com")
+				f.db.ExpectQuery("SELECT * FROM users ORDER BY id").WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: ExportUsers source: filtered users",
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.UserFilter{
+					Email:  "100%_doe",
+					Name:   "John",
+					Role:   entity.RoleAdmin,
+					MinAge: &minAge,
+				},
+			},
+			want: []string{"100%_doe@example.com"},
+			setup: func(a args, f fields) {
+				rows := sqlmock.NewRows([]string{"id", "email"}).
+					AddRow(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"), "100%_doe@example.com")
+				f.db.ExpectQuery("SELECT * FROM users WHERE email ILIKE $1 AND (first_name ILIKE $2 OR second_name ILIKE $2 OR last_name ILIKE $2) AND role = $3 AND age >= $4 ORDER BY id").
+					WithArgs(`%100\%\_doe%`, "%John%", "admin", 18).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error: ExportUsers source: can't exec query",
+			args: args{
+				ctx:    context.Background(),
+				filter: &entity.UserFilter{},
+			},
+			want: nil,
+			setup: func(a args, f fields) {
+				f.db.ExpectQuery("SELECT * FROM users ORDER BY id").WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			f := fields{
+				db: mock,
+			}
+
+			s := &source{
+				db: sqlx.NewDb(db, "sqlmock"),
+			}
+
+			tt.setup(tt.args, f)
+
+			var got []string
+			err = s.ExportUsers(tt.args.ctx, tt.args.filter, func(user *entity.UserDB) error {
+				got = append(got, user.Email)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("source.ExportUsers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("source.ExportUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
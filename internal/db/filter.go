@@ -0,0 +1,45 @@
+package db
+
+import (
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"strings"
+)
+
+// likeEscaper экранирует спецсимволы шаблона LIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// buildUserFilter строит условие WHERE для фильтра пользователей
+func buildUserFilter(filter *entity.UserFilter) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Email != "" {
+		conds = append(conds, "email ILIKE "+arg("%"+likeEscaper.Replace(filter.Email)+"%"))
+	}
+	if filter.Name != "" {
+		name := arg("%" + likeEscaper.Replace(filter.Name) + "%")
+		conds = append(conds, fmt.Sprintf("(first_name ILIKE %[1]s OR second_name ILIKE %[1]s OR last_name ILIKE %[1]s)", name))
+	}
+	if filter.Role != "" {
+		conds = append(conds, "role = "+arg(string(filter.Role)))
+	}
+	if filter.MinAge != nil {
+		conds = append(conds, "age >= "+arg(*filter.MinAge))
+	}
+	if filter.MaxAge != nil {
+		conds = append(conds, "age <= "+arg(*filter.MaxAge))
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"time"
+)
+
+// GetUserAudit возвращает до limit действий, выполненных пользователем, с id меньше cursor (0 - с начала),
+// от новых к старым. Снимки данных не выбираются, так как относятся к другим пользователям.
+func (s *source) GetUserAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	query := "SELECT id, user_id, action, actor_id, created_at FROM user_history WHERE actor_id = $1"
+	args := []interface{}{id.String()}
+	if cursor > 0 {
+		args = append(args, cursor)
+		query += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	var entries []*entity.UserHistoryDB
+	if err := s.db.SelectContext(dbCtx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return entries, nil
+}
+
+// erasedSnapshot поля снимка истории, которые затираются при удалении персональных данных
+var erasedSnapshot = func() string {
+	data, _ := json.Marshal(map[string]interface{}{
+		"first_name":  "",
+		"second_name": "",
+		"last_name":   "",
+		"age":         0,
+		"email":       "",
+		"phone":       "",
+	})
+	return string(data)
+}()
+
+// EraseUser обезличивает пользователя: персональные данные в users и в снимках user_history
+// затираются, а сами записи и их связи сохраняются. Для уже удаленного пользователя очищается
+// только история. Повторный вызов для обезличенного пользователя ничего не меняет.
+// Если нет ни пользователя, ни его истории, возвращает entity.ErrUserNotFound.
+func (s *source) EraseUser(ctx context.Context, id *entity.UserID) error {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := s.getUserForUpdate(dbCtx, tx, id)
+	if err != nil && !errors.Is(err, entity.ErrUserNotFound) {
+		return fmt.Errorf("can't get user: %w", err)
+	}
+	if before != nil && before.ErasedAt != nil {
+		return nil
+	}
+
+	// Пользователь мог быть удален раньше, но его данные остались в снимках истории
+	var after *entity.UserDB
+	if before != nil {
+		now := time.Now().UTC()
+		row := tx.QueryRowxContext(dbCtx, "UPDATE users SET first_name = '', last_name = '', second_name = '', age = 0, email = $1, phone = '', password = '', erased_at = $2, updated_at = $2 WHERE id = $3 RETURNING *",
+			entity.ErasedEmail(id), now, id.String())
+		if row.Err() != nil {
+			return fmt.Errorf("can't exec query: %w", row.Err())
+		}
+
+		after = &entity.UserDB{}
+		if err := row.StructScan(after); err != nil {
+			return fmt.Errorf("can't scan user: %w", err)
+		}
+	}
+
+	res, err := tx.ExecContext(dbCtx, "UPDATE user_history SET before = before || $1::jsonb, after = after || $1::jsonb WHERE user_id = $2",
+		erasedSnapshot, id.String())
+	if err != nil {
+		return fmt.Errorf("can't erase history: %w", err)
+	}
+	if before == nil {
+		erased, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("can't get affected rows: %w", err)
+		}
+		if erased == 0 {
+			return entity.ErrUserNotFound
+		}
+	}
+
+	// Снимок до удаления не сохраняется, иначе персональные данные останутся в истории
+	err = s.insertHistory(dbCtx, tx, entity.UserHistoryErase, id.Id, nil, after)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-test-grpc-http/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func Test_source_EraseUser(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx context.Context
+		id  *entity.UserID
+	}
+	const (
+		eraseUserQuery    = "UPDATE users SET first_name = '', last_name = '', second_name = '', age = 0, email = $1, phone = '', password = '', erased_at = $2, updated_at = $2 WHERE id = $3 RETURNING *"
+		eraseHistoryQuery = "UPDATE user_history SET before = before || $1::jsonb, after = after || $1::jsonb WHERE user_id = $2"
+	)
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	erasedAt := time.Date(2023, 8, 2, 12, 0, 0, 0, time.UTC)
+	user := &entity.UserDB{
+		ID:        id,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "doe@example.com",
+		Role:      entity.RoleUser,
+	}
+	erased := &entity.UserDB{
+		ID:       id,
+		Email:    entity.ErasedEmail(&entity.UserID{Id: id}),
+		Role:     entity.RoleUser,
+		ErasedAt: &erasedAt,
+	}
+	erasedRows := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "email", "role", "erased_at"})
+		return rows.AddRow(erased.ID, erased.Email, erased.Role, erased.ErasedAt)
+	}
+	tests := []struct {
+		name    string
+		args    args
+		setup   func(a args, f fields)
+		wantErr error
+	}{
+		{
+			name: "success: EraseUser source: user erased",
+			args: args{
+				ctx: context.Background(),
+				id:  &entity.UserID{Id: id},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(newUserRows(user))
+				f.db.ExpectQuery(eraseUserQuery).
+					WithArgs(erased.Email, sqlmock.AnyArg(), a.id.String()).
+					WillReturnRows(erasedRows())
+				f.db.ExpectExec(eraseHistoryQuery).
+					WithArgs(erasedSnapshot, a.id.String()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "erase", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name: "success: EraseUser source: already erased",
+			args: args{
+				ctx: context.Background(),
+				id:  &entity.UserID{Id: id},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(erasedRows())
+				f.db.ExpectRollback()
+			},
+		},
+		{
+			name: "success: EraseUser source: deleted user history erased",
+			args: args{
+				ctx: context.Background(),
+				id:  &entity.UserID{Id: id},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectExec(eraseHistoryQuery).
+					WithArgs(erasedSnapshot, a.id.String()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "erase", nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name: "error: EraseUser source: user not found",
+			args: args{
+				ctx: context.Background(),
+				id:  &entity.UserID{Id: id},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectExec(eraseHistoryQuery).
+					WithArgs(erasedSnapshot, a.id.String()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				f.db.ExpectRollback()
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name: "error: EraseUser source: can't erase history",
+			args: args{
+				ctx: context.Background(),
+				id:  &entity.UserID{Id: id},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(newUserRows(user))
+				f.db.ExpectQuery(eraseUserQuery).WillReturnRows(erasedRows())
+				f.db.ExpectExec(eraseHistoryQuery).WillReturnError(errQuery)
+				f.db.ExpectRollback()
+			},
+			wantErr: errQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			f := fields{
+				db: mock,
+			}
+
+			s := &source{
+				db: sqlx.NewDb(db, "sqlmock"),
+			}
+
+			tt.setup(tt.args, f)
+
+			if err := s.EraseUser(tt.args.ctx, tt.args.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("source.EraseUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("source.EraseUser() unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetUserHistory возвращает до limit записей истории пользователя с id меньше cursor (0 - с начала),
+// от новых к старым
+func (s *source) GetUserHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	query := "SELECT * FROM user_history WHERE user_id = $1"
+	args := []interface{}{id.String()}
+	if cursor > 0 {
+		args = append(args, cursor)
+		query += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	var entries []*entity.UserHistoryDB
+	if err := s.db.SelectContext(dbCtx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("can't exec query: %w", err)
+	}
+
+	return entries, nil
+}
+
+// insertHistory записывает изменение пользователя в историю в транзакции tx.
+// Исполнитель берется из контекста (entity.ActorFromContext).
+func (s *source) insertHistory(ctx context.Context, tx *sqlx.Tx, action entity.UserHistoryAction, userID uuid.UUID, before, after *entity.UserDB) error {
+	beforeJSON, err := marshalSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := marshalSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	var actorID uuid.NullUUID
+	if actor := entity.ActorFromContext(ctx); actor != nil {
+		actorID = uuid.NullUUID{UUID: actor.Id, Valid: true}
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO user_history (user_id, action, actor_id, before, after, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		userID, string(action), actorID, beforeJSON, afterJSON, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("can't insert history: %w", err)
+	}
+
+	return nil
+}
+
+// marshalSnapshot возвращает снимок пользователя в виде JSON-строки или nil.
+// Строка, а не []byte, чтобы lib/pq передавал значение в текстовом формате jsonb.
+func marshalSnapshot(user *entity.UserDB) (interface{}, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity.NewUserSnapshot(user))
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal user snapshot: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func Test_source_GetUserHistory(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx    context.Context
+		id     *entity.UserID
+		cursor int64
+		limit  int
+	}
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	createdAt := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "action", "actor_id", "before", "after", "created_at"}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*entity.UserHistoryDB
+		setup   func(a args, f fields)
+		wantErr bool
+	}{
+		{
+			name: "success: GetUserHistory source: first page",
+			args: args{
+				ctx:   context.Background(),
+				id:    &entity.UserID{Id: id},
+				limit: 2,
+			},
+			want: []*entity.UserHistoryDB{
+				{ID: 2, UserID: id, Action: "update", Before: []byte(`{}`), After: []byte(`{}`), CreatedAt: createdAt},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectQuery("SELECT * FROM user_history WHERE user_id = $1 ORDER BY id DESC LIMIT $2").
+					WithArgs(a.id.String(), 2).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, id, "update", nil, []byte(`{}`), []byte(`{}`), createdAt))
+			},
+		},
+		{
+			name: "success: GetUserHistory source: page after cursor",
+			args: args{
+				ctx:    context.Background(),
+				id:     &entity.UserID{Id: id},
+				cursor: 2,
+				limit:  2,
+			},
+			want: []*entity.UserHistoryDB{
+				{ID: 1, UserID: id, Action: "create", After: []byte(`{}`), CreatedAt: createdAt},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectQuery("SELECT * FROM user_history WHERE user_id = $1 AND id < $2 ORDER BY id DESC LIMIT $3").
+					WithArgs(a.id.String(), int64(2), 2).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, "create", nil, nil, []byte(`{}`), createdAt))
+			},
+		},
+		{
+			name: "error: GetUserHistory source: can't exec query",
+			args: args{
+				ctx:   context.Background(),
+				id:    &entity.UserID{Id: id},
+				limit: 2,
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectQuery("SELECT * FROM user_history WHERE user_id = $1 ORDER BY id DESC LIMIT $2").
+					WillReturnError(fmt.Errorf("can't exec query"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			f := fields{
+				db: mock,
+			}
+
+			s := &source{
+				db: sqlx.NewDb(db, "sqlmock"),
+			}
+
+			tt.setup(tt.args, f)
+
+			got, err := s.GetUserHistory(tt.args.ctx, tt.args.id, tt.args.cursor, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("source.GetUserHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("source.GetUserHistory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"io"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ImportUsers создает пользователей пачками, получаемыми из next.
+//
+//	В режиме all-or-nothing весь импорт выполняется в одной транзакции и фиксируется,
+//	только если ни одна строка не завершилась ошибкой. В режиме best-effort каждая пачка
+//	выполняется в отдельной транзакции, а ошибочные строки откатываются до точки сохранения.
+//	При dry-run все транзакции откатываются.
+func (s *source) ImportUsers(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error) {
+	report := &entity.UserImportReport{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+	}
+
+	var tx *sqlx.Tx
+	if opts.Mode == entity.ImportModeAllOrNothing {
+		var err error
+		tx, err = s.db.BeginTxx(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("can't begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+	}
+
+	failed := 0
+	for {
+		batch, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't read import batch: %w", err)
+		}
+
+		if tx != nil {
+			results, batchFailed, err := s.importBatch(ctx, tx, batch)
+			if err != nil {
+				return nil, err
+			}
+			failed += batchFailed
+			report.Results = append(report.Results, results...)
+			continue
+		}
+
+		results, err := s.importBatchTx(ctx, batch, opts.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, results...)
+	}
+
+	if tx != nil {
+		commit := failed == 0 && !opts.DryRun
+		if commit {
+			if err := tx.Commit(); err != nil {
+				return nil, fmt.Errorf("can't commit transaction: %w", err)
+			}
+		} else {
+			markRolledBack(report.Results, opts.DryRun)
+		}
+		report.Committed = commit
+	} else {
+		report.Committed = !opts.DryRun
+	}
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case entity.ImportStatusCreated:
+			report.Created++
+		case entity.ImportStatusFailed:
+			report.Failed++
+		}
+	}
+	report.Total = len(report.Results)
+
+	return report, nil
+}
+
+// importBatchTx импортирует пачку в отдельной транзакции
+func (s *source) importBatchTx(ctx context.Context, batch []*entity.UserImportRow, dryRun bool) ([]*entity.UserImportResult, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	results, _, err := s.importBatch(ctx, tx, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		markRolledBack(results, dryRun)
+		return results, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// importBatch вставляет строки пачки, изолируя ошибку каждой строки точкой сохранения
+func (s *source) importBatch(ctx context.Context, tx *sqlx.Tx, batch []*entity.UserImportRow) ([]*entity.UserImportResult, int, error) {
+	results := make([]*entity.UserImportResult, 0, len(batch))
+	failed := 0
+	for _, row := range batch {
+		result := &entity.UserImportResult{
+			Line: row.Line,
+		}
+		if row.User != nil {
+			result.Email = row.User.Email
+		}
+		results = append(results, result)
+
+		if row.Err != nil {
+			result.Status = entity.ImportStatusFailed
+			result.Error = row.Err.Error()
+			failed++
+			continue
+		}
+
+		id, err := s.importRow(ctx, tx, row.User)
+		if err != nil {
+			var rowErr *importRowError
+			if !errors.As(err, &rowErr) {
+				return nil, 0, err
+			}
+			result.Status = entity.ImportStatusFailed
+			result.Error = rowErr.Error()
+			failed++
+			continue
+		}
+
+		result.ID = id
+		result.Status = entity.ImportStatusCreated
+	}
+
+	return results, failed, nil
+}
+
+// importRowError ошибка вставки строки, после которой импорт может быть продолжен
+type importRowError struct {
+	err error
+}
+
+func (e *importRowError) Error() string {
+	return e.err.Error()
+}
+
+func (e *importRowError) Unwrap() error {
+	return e.err
+}
+
+func (s *source) importRow(ctx context.Context, tx *sqlx.Tx, user *entity.UserCreate) (*entity.UserID, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	if _, err := tx.ExecContext(dbCtx, "SAVEPOINT import_row"); err != nil {
+		return nil, fmt.Errorf("can't create savepoint: %w", err)
+	}
+
+	userDB, err := s.insertUser(dbCtx, tx, user)
+	if err == nil {
+		err = s.insertHistory(dbCtx, tx, entity.UserHistoryCreate, userDB.ID, nil, userDB)
+	}
+	if err != nil {
+		if _, rbErr := tx.ExecContext(dbCtx, "ROLLBACK TO SAVEPOINT import_row"); rbErr != nil {
+			return nil, fmt.Errorf("can't rollback to savepoint: %w", rbErr)
+		}
+		return nil, &importRowError{err: err}
+	}
+
+	if _, err := tx.ExecContext(dbCtx, "RELEASE SAVEPOINT import_row"); err != nil {
+		return nil, fmt.Errorf("can't release savepoint: %w", err)
+	}
+
+	return &entity.UserID{
+		Id: userDB.ID,
+	}, nil
+}
+
+// markRolledBack помечает созданных в откатанной транзакции пользователей
+func markRolledBack(results []*entity.UserImportResult, dryRun bool) {
+	for _, result := range results {
+		if result.Status != entity.ImportStatusCreated {
+			continue
+		}
+		result.ID = nil
+		if dryRun {
+			result.Status = entity.ImportStatusValid
+		} else {
+			result.Status = entity.ImportStatusRolledBack
+		}
+	}
+}
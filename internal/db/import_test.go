@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func Test_source_ImportUsers(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	type args struct {
+		ctx     context.Context
+		opts    *entity.UserImportOptions
+		batches [][]*entity.UserImportRow
+	}
+	newRow := func(line int, email string) *entity.UserImportRow {
+		return &entity.UserImportRow{
+			Line: line,
+			User: &entity.UserCreate{
+				FirstName: "John",
+				LastName:  "Doe",
+				Age:       30,
+				Email:     email,
+				Password:  "qwerty1234",
+			},
+		}
+	}
+	expectInsert := func(f fields, email string) {
+		f.db.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+		f.db.ExpectQuery(insertUserQuery).
+			WithArgs(sqlmock.AnyArg(), "John", "Doe", "", 30, email, "", "qwerty1234", entity.RoleUser, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(uuid.New(), email))
+		f.db.ExpectExec(insertHistoryQuery).
+			WithArgs(sqlmock.AnyArg(), "create", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		f.db.ExpectExec("RELEASE SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantStatuses []entity.ImportStatus
+		wantCommit   bool
+		setup        func(a args, f fields)
+		wantErr      bool
+	}{
+		{
+			name: "success: ImportUsers source: all-or-nothing committed",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserImportOptions{Mode: entity.ImportModeAllOrNothing},
+				batches: [][]*entity.UserImportRow{
+					{newRow(2, "a@example.com")},
+					{newRow(3, "b@example.com")},
+				},
+			},
+			wantStatuses: []entity.ImportStatus{entity.ImportStatusCreated, entity.ImportStatusCreated},
+			wantCommit:   true,
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				expectInsert(f, "a@example.com")
+				expectInsert(f, "b@example.com")
+				f.db.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: ImportUsers source: all-or-nothing rolled back on invalid row",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserImportOptions{Mode: entity.ImportModeAllOrNothing},
+				batches: [][]*entity.UserImportRow{
+					{
+						newRow(2, "a@example.com"),
+						{Line: 3, User: &entity.UserCreate{}, Err: fmt.Errorf("email is invalid")},
+					},
+				},
+			},
+			wantStatuses: []entity.ImportStatus{entity.ImportStatusRolledBack, entity.ImportStatusFailed},
+			wantCommit:   false,
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				expectInsert(f, "a@example.com")
+				f.db.ExpectRollback()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: ImportUsers source: best-effort skips failed insert",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserImportOptions{Mode: entity.ImportModeBestEffort},
+				batches: [][]*entity.UserImportRow{
+					{newRow(2, "a@example.com"), newRow(3, "b@example.com")},
+				},
+			},
+			wantStatuses: []entity.ImportStatus{entity.ImportStatusFailed, entity.ImportStatusCreated},
+			wantCommit:   true,
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectExec("SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+				f.db.ExpectQuery(insertUserQuery).WillReturnError(fmt.Errorf("value too long"))
+				f.db.ExpectExec("ROLLBACK TO SAVEPOINT import_row").WillReturnResult(sqlmock.NewResult(0, 0))
+				expectInsert(f, "b@example.com")
+				f.db.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: ImportUsers source: best-effort dry run",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserImportOptions{Mode: entity.ImportModeBestEffort, DryRun: true},
+				batches: [][]*entity.UserImportRow{
+					{newRow(2, "a@example.com")},
+				},
+			},
+			wantStatuses: []entity.ImportStatus{entity.ImportStatusValid},
+			wantCommit:   false,
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin()
+				expectInsert(f, "a@example.com")
+				f.db.ExpectRollback()
+			},
+			wantErr: false,
+		},
+		{
+			name: "error: ImportUsers source: can't begin transaction",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserImportOptions{Mode: entity.ImportModeAllOrNothing},
+			},
+			setup: func(a args, f fields) {
+				f.db.ExpectBegin().WillReturnError(fmt.Errorf("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			f := fields{
+				db: mock,
+			}
+
+			s := &source{
+				db: sqlx.NewDb(db, "sqlmock"),
+			}
+
+			tt.setup(tt.args, f)
+
+			batches := tt.args.batches
+			next := func() ([]*entity.UserImportRow, error) {
+				if len(batches) == 0 {
+					return nil, io.EOF
+				}
+				batch := batches[0]
+				batches = batches[1:]
+				return batch, nil
+			}
+
+			got, err := s.ImportUsers(tt.args.ctx, tt.args.opts, next)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("source.ImportUsers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("source.ImportUsers() unmet expectations: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var statuses []entity.ImportStatus
+			for _, result := range got.Results {
+				statuses = append(statuses, result.Status)
+				if (result.ID != nil) != (result.Status == entity.ImportStatusCreated) {
+					t.Errorf("source.ImportUsers() line %d: id = %v, status %v", result.Line, result.ID, result.Status)
+				}
+			}
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("source.ImportUsers() statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+			if got.Committed != tt.wantCommit {
+				t.Errorf("source.ImportUsers() committed = %v, want %v", got.Committed, tt.wantCommit)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/metrics"
+	"go-test-grpc-http/internal/tracing"
+	"time"
+)
+
+// instrumentedSource учитывает в метриках время выполнения методов источника данных
+// и создает для каждого вызова спан UserSource.<метод>
+type instrumentedSource struct {
+	next UserSource
+}
+
+// Instrument оборачивает источник данных метриками и трассировкой каждого метода
+func Instrument(next UserSource) UserSource {
+	return &instrumentedSource{
+		next: next,
+	}
+}
+
+// start начинает спан метода method и возвращает функцию его завершения с учетом метрик
+func (s *instrumentedSource) start(ctx context.Context, method string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "UserSource."+method)
+
+	return ctx, func(err error) {
+		metrics.ObserveQuery(method, start, err)
+		tracing.End(span, err)
+	}
+}
+
+func (s *instrumentedSource) CreateUser(ctx context.Context, user *entity.UserCreate) (id *entity.UserID, err error) {
+	ctx, end := s.start(ctx, "CreateUser")
+	defer func() { end(err) }()
+	return s.next.CreateUser(ctx, user)
+}
+
+func (s *instrumentedSource) GetUserById(ctx context.Context, id *entity.UserID) (user *entity.UserDB, err error) {
+	ctx, end := s.start(ctx, "GetUserById")
+	defer func() { end(err) }()
+	return s.next.GetUserById(ctx, id)
+}
+
+func (s *instrumentedSource) GetUserByEmail(ctx context.Context, email string) (user *entity.UserDB, err error) {
+	ctx, end := s.start(ctx, "GetUserByEmail")
+	defer func() { end(err) }()
+	return s.next.GetUserByEmail(ctx, email)
+}
+
+func (s *instrumentedSource) GetUserIdByEmail(ctx context.Context, email string) (id *entity.UserID, err error) {
+	ctx, end := s.start(ctx, "GetUserIdByEmail")
+	defer func() { end(err) }()
+	return s.next.GetUserIdByEmail(ctx, email)
+}
+
+func (s *instrumentedSource) UpdateUser(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (userDB *entity.UserDB, err error) {
+	ctx, end := s.start(ctx, "UpdateUser")
+	defer func() { end(err) }()
+	return s.next.UpdateUser(ctx, id, user)
+}
+
+func (s *instrumentedSource) DeleteUser(ctx context.Context, id *entity.UserID) (err error) {
+	ctx, end := s.start(ctx, "DeleteUser")
+	defer func() { end(err) }()
+	return s.next.DeleteUser(ctx, id)
+}
+
+func (s *instrumentedSource) SetUserRole(ctx context.Context, id *entity.UserID, role entity.Role) (user *entity.UserDB, err error) {
+	ctx, end := s.start(ctx, "SetUserRole")
+	defer func() { end(err) }()
+	return s.next.SetUserRole(ctx, id, role)
+}
+
+func (s *instrumentedSource) SetUserDisabled(ctx context.Context, id *entity.UserID, disabled bool) (user *entity.UserDB, err error) {
+	ctx, end := s.start(ctx, "SetUserDisabled")
+	defer func() { end(err) }()
+	return s.next.SetUserDisabled(ctx, id, disabled)
+}
+
+func (s *instrumentedSource) SetUserPassword(ctx context.Context, id *entity.UserID, password string) (user *entity.UserDB, err error) {
+	ctx, end := s.start(ctx, "SetUserPassword")
+	defer func() { end(err) }()
+	return s.next.SetUserPassword(ctx, id, password)
+}
+
+func (s *instrumentedSource) GetUserHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (history []*entity.UserHistoryDB, err error) {
+	ctx, end := s.start(ctx, "GetUserHistory")
+	defer func() { end(err) }()
+	return s.next.GetUserHistory(ctx, id, cursor, limit)
+}
+
+func (s *instrumentedSource) GetUserAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) (history []*entity.UserHistoryDB, err error) {
+	ctx, end := s.start(ctx, "GetUserAudit")
+	defer func() { end(err) }()
+	return s.next.GetUserAudit(ctx, id, cursor, limit)
+}
+
+func (s *instrumentedSource) EraseUser(ctx context.Context, id *entity.UserID) (err error) {
+	ctx, end := s.start(ctx, "EraseUser")
+	defer func() { end(err) }()
+	return s.next.EraseUser(ctx, id)
+}
+
+func (s *instrumentedSource) ExportUsers(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.UserDB) error) (err error) {
+	ctx, end := s.start(ctx, "ExportUsers")
+	defer func() { end(err) }()
+	return s.next.ExportUsers(ctx, filter, fn)
+}
+
+func (s *instrumentedSource) ImportUsers(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (report *entity.UserImportReport, err error) {
+	ctx, end := s.start(ctx, "ImportUsers")
+	defer func() { end(err) }()
+	return s.next.ImportUsers(ctx, opts, next)
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/metrics"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestInstrument(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status string
+	}{
+		{name: "ok", err: nil, status: metrics.StatusOK},
+		{name: "error", err: entity.ErrUserNotFound, status: metrics.StatusError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			source := NewMockUserSource(ctrl)
+			source.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(tt.err)
+
+			histogram := metrics.DBQueryDuration.WithLabelValues("DeleteUser", tt.status).(prometheus.Histogram)
+			before := sampleCount(t, histogram)
+
+			err := Instrument(source).DeleteUser(context.Background(), &entity.UserID{})
+			if err != tt.err {
+				t.Errorf("DeleteUser() error = %v, want %v", err, tt.err)
+			}
+			if got := sampleCount(t, histogram) - before; got != 1 {
+				t.Errorf("query duration observed %d times, want 1", got)
+			}
+		})
+	}
+}
+
+func sampleCount(t *testing.T, histogram prometheus.Histogram) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := histogram.Write(&m); err != nil {
+		t.Fatalf("can't write metric: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
@@ -14,4 +14,12 @@ type UserSource interface {
 	GetUserIdByEmail(ctx context.Context, email string) (*entity.UserID, error)
 	UpdateUser(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.UserDB, error)
 	DeleteUser(ctx context.Context, id *entity.UserID) error
+	SetUserRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.UserDB, error)
+	SetUserDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.UserDB, error)
+	SetUserPassword(ctx context.Context, id *entity.UserID, password string) (*entity.UserDB, error)
+	GetUserHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error)
+	GetUserAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error)
+	EraseUser(ctx context.Context, id *entity.UserID) error
+	ExportUsers(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.UserDB) error) error
+	ImportUsers(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error)
 }
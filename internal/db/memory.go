@@ -0,0 +1,632 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memorySource хранит пользователей и их историю в памяти процесса. Предназначен для тестов и локальной
+// разработки без PostgreSQL и повторяет поведение source: уникальность email без учета регистра,
+// entity.ErrUserNotFound, порядок выборок и откат импорта. Изменения выполняются под общей блокировкой,
+// поэтому видны другим вызовам только целиком.
+type memorySource struct {
+	mu sync.RWMutex
+	// users пользователи по id
+	users map[uuid.UUID]*entity.UserDB
+	// emails id пользователей по email в нижнем регистре, как в индексе users_email_lower_idx.
+	// Пробелы по краям, как и в PostgreSQL, не убираются: email нормализует сценарий использования.
+	emails map[string]uuid.UUID
+	// history записи истории в порядке возрастания id
+	history   []*entity.UserHistoryDB
+	historyID int64
+}
+
+func NewMemorySource() *memorySource {
+	return &memorySource{
+		users:  make(map[uuid.UUID]*entity.UserDB),
+		emails: make(map[string]uuid.UUID),
+	}
+}
+
+func (s *memorySource) CreateUser(ctx context.Context, user *entity.UserCreate) (*entity.UserID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tx memoryTx
+	userDB, err := s.insertUser(ctx, &tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.UserID{
+		Id: userDB.ID,
+	}, nil
+}
+
+func (s *memorySource) GetUserById(ctx context.Context, id *entity.UserID) (*entity.UserDB, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id.Id]
+	if !ok {
+		return nil, fmt.Errorf("can't get user: %w", entity.ErrUserNotFound)
+	}
+
+	return cloneUser(user), nil
+}
+
+func (s *memorySource) GetUserByEmail(ctx context.Context, email string) (*entity.UserDB, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.emails[strings.ToLower(email)]
+	if !ok {
+		return nil, fmt.Errorf("can't get user: %w", entity.ErrUserNotFound)
+	}
+
+	return cloneUser(s.users[id]), nil
+}
+
+func (s *memorySource) GetUserIdByEmail(ctx context.Context, email string) (*entity.UserID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.emails[strings.ToLower(email)]
+	if !ok {
+		return nil, fmt.Errorf("can't get user id: %w", entity.ErrUserNotFound)
+	}
+
+	return &entity.UserID{
+		Id: id,
+	}, nil
+}
+
+func (s *memorySource) UpdateUser(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.UserDB, error) {
+	return s.patchUser(ctx, id, func(u *entity.UserDB, _ time.Time) {
+		u.FirstName = user.FirstName
+		u.LastName = user.LastName
+		u.SecondName = user.SecondName
+		u.Age = user.Age
+		u.Email = user.Email
+		u.Phone = user.Phone
+		u.Password = user.Password
+	})
+}
+
+func (s *memorySource) DeleteUser(ctx context.Context, id *entity.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.users[id.Id]
+	if !ok {
+		return fmt.Errorf("can't get user: %w", entity.ErrUserNotFound)
+	}
+	delete(s.users, id.Id)
+	delete(s.emails, strings.ToLower(before.Email))
+
+	return s.insertHistory(ctx, &memoryTx{}, entity.UserHistoryDelete, id.Id, before, nil)
+}
+
+func (s *memorySource) SetUserRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.UserDB, error) {
+	return s.patchUser(ctx, id, func(u *entity.UserDB, _ time.Time) {
+		u.Role = role
+	})
+}
+
+func (s *memorySource) SetUserDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.UserDB, error) {
+	return s.patchUser(ctx, id, func(u *entity.UserDB, now time.Time) {
+		switch {
+		case !disabled:
+			u.DisabledAt = nil
+		case u.DisabledAt == nil:
+			u.DisabledAt = &now
+		}
+	})
+}
+
+func (s *memorySource) SetUserPassword(ctx context.Context, id *entity.UserID, password string) (*entity.UserDB, error) {
+	return s.patchUser(ctx, id, func(u *entity.UserDB, _ time.Time) {
+		u.Password = password
+	})
+}
+
+// patchUser изменяет копию пользователя функцией patch, проверяет уникальность email
+// и записывает изменение в историю
+func (s *memorySource) patchUser(ctx context.Context, id *entity.UserID, patch func(user *entity.UserDB, now time.Time)) (*entity.UserDB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.users[id.Id]
+	if !ok {
+		return nil, fmt.Errorf("can't get user: %w", entity.ErrUserNotFound)
+	}
+
+	now := memoryNow()
+	after := cloneUser(before)
+	patch(after, now)
+	after.UpdatedAt = now
+
+	if other, ok := s.emails[strings.ToLower(after.Email)]; ok && other != id.Id {
+		return nil, fmt.Errorf("can't exec query: %w", entity.ErrEmailTaken)
+	}
+	delete(s.emails, strings.ToLower(before.Email))
+	s.emails[strings.ToLower(after.Email)] = id.Id
+	s.users[id.Id] = after
+
+	err := s.insertHistory(ctx, &memoryTx{}, entity.UserHistoryUpdate, id.Id, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneUser(after), nil
+}
+
+// GetUserHistory возвращает до limit записей истории пользователя с id меньше cursor (0 - с начала),
+// от новых к старым
+func (s *memorySource) GetUserHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error) {
+	return s.selectHistory(cursor, limit, func(entry *entity.UserHistoryDB) *entity.UserHistoryDB {
+		if entry.UserID != id.Id {
+			return nil
+		}
+		return cloneHistory(entry)
+	}), nil
+}
+
+// GetUserAudit возвращает до limit действий, выполненных пользователем, с id меньше cursor (0 - с начала),
+// от новых к старым, без снимков данных
+func (s *memorySource) GetUserAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error) {
+	return s.selectHistory(cursor, limit, func(entry *entity.UserHistoryDB) *entity.UserHistoryDB {
+		if !entry.ActorID.Valid || entry.ActorID.UUID != id.Id {
+			return nil
+		}
+		return &entity.UserHistoryDB{
+			ID:        entry.ID,
+			UserID:    entry.UserID,
+			Action:    entry.Action,
+			ActorID:   entry.ActorID,
+			CreatedAt: entry.CreatedAt,
+		}
+	}), nil
+}
+
+// selectHistory выбирает от новых к старым записи, для которых pick возвращает не nil
+func (s *memorySource) selectHistory(cursor int64, limit int, pick func(entry *entity.UserHistoryDB) *entity.UserHistoryDB) []*entity.UserHistoryDB {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []*entity.UserHistoryDB
+	for i := len(s.history) - 1; i >= 0 && len(entries) < limit; i-- {
+		entry := s.history[i]
+		if cursor > 0 && entry.ID >= cursor {
+			continue
+		}
+		if picked := pick(entry); picked != nil {
+			entries = append(entries, picked)
+		}
+	}
+
+	return entries
+}
+
+// EraseUser обезличивает пользователя так же, как source.EraseUser
+func (s *memorySource) EraseUser(ctx context.Context, id *entity.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := s.users[id.Id]
+	if before != nil && before.ErasedAt != nil {
+		return nil
+	}
+
+	var after *entity.UserDB
+	if before != nil {
+		now := memoryNow()
+		after = &entity.UserDB{
+			ID:         before.ID,
+			Email:      entity.ErasedEmail(id),
+			Role:       before.Role,
+			CreatedAt:  before.CreatedAt,
+			UpdatedAt:  now,
+			ErasedAt:   &now,
+			DisabledAt: before.DisabledAt,
+		}
+	}
+
+	// Снимки меняются на копиях, чтобы при ошибке не оставить историю наполовину обезличенной
+	erased := make(map[int]*entity.UserHistoryDB)
+	for i, entry := range s.history {
+		if entry.UserID != id.Id {
+			continue
+		}
+		changed := cloneHistory(entry)
+		var err error
+		if changed.Before, err = eraseSnapshot(entry.Before); err != nil {
+			return err
+		}
+		if changed.After, err = eraseSnapshot(entry.After); err != nil {
+			return err
+		}
+		erased[i] = changed
+	}
+	if before == nil && len(erased) == 0 {
+		return entity.ErrUserNotFound
+	}
+
+	for i, entry := range erased {
+		s.history[i] = entry
+	}
+	if after != nil {
+		delete(s.emails, strings.ToLower(before.Email))
+		s.emails[strings.ToLower(after.Email)] = id.Id
+		s.users[id.Id] = after
+	}
+
+	// Снимок до удаления не сохраняется, иначе персональные данные останутся в истории
+	return s.insertHistory(ctx, &memoryTx{}, entity.UserHistoryErase, id.Id, nil, after)
+}
+
+// ExportUsers построчно передает в fn пользователей, подходящих под фильтр, в порядке id.
+// fn вызывается без блокировки и получает пользователей в состоянии на момент начала выгрузки.
+func (s *memorySource) ExportUsers(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.UserDB) error) error {
+	s.mu.RLock()
+	users := make([]*entity.UserDB, 0, len(s.users))
+	for _, user := range s.users {
+		if matchUserFilter(filter, user) {
+			users = append(users, cloneUser(user))
+		}
+	}
+	s.mu.RUnlock()
+
+	// uuid в PostgreSQL сравниваются побайтно
+	sort.Slice(users, func(i, j int) bool {
+		return bytes.Compare(users[i].ID[:], users[j].ID[:]) < 0
+	})
+
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("can't read users: %w", err)
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportUsers создает пользователей пачками из next с теми же режимами, что и source.ImportUsers.
+// В режиме all-or-nothing все пачки читаются до блокировки, чтобы разбор загрузки не задерживал
+// остальные запросы; блокировка удерживается только на время вставки. В режиме best-effort
+// блокировка берется на каждую пачку.
+func (s *memorySource) ImportUsers(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error) {
+	report := &entity.UserImportReport{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+	}
+
+	if opts.Mode == entity.ImportModeAllOrNothing {
+		var batches [][]*entity.UserImportRow
+		err := readImportBatches(next, func(batch []*entity.UserImportRow) {
+			batches = append(batches, batch)
+		})
+		if err != nil {
+			return nil, err
+		}
+		report.Results, report.Committed = s.importAllTx(ctx, batches, opts.DryRun)
+	} else {
+		err := readImportBatches(next, func(batch []*entity.UserImportRow) {
+			report.Results = append(report.Results, s.importBatchTx(ctx, batch, opts.DryRun)...)
+		})
+		if err != nil {
+			return nil, err
+		}
+		report.Committed = !opts.DryRun
+	}
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case entity.ImportStatusCreated:
+			report.Created++
+		case entity.ImportStatusFailed:
+			report.Failed++
+		}
+	}
+	report.Total = len(report.Results)
+
+	return report, nil
+}
+
+// readImportBatches передает в fn пачки из next до io.EOF
+func readImportBatches(next entity.UserImportBatchFunc, fn func(batch []*entity.UserImportRow)) error {
+	for {
+		batch, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("can't read import batch: %w", err)
+		}
+		fn(batch)
+	}
+}
+
+// importAllTx импортирует все пачки в одной транзакции. Транзакция фиксируется,
+// только если все строки вставлены и это не пробный запуск.
+func (s *memorySource) importAllTx(ctx context.Context, batches [][]*entity.UserImportRow, dryRun bool) ([]*entity.UserImportResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &memoryTx{}
+	defer tx.rollback()
+
+	var results []*entity.UserImportResult
+	failed := 0
+	for _, batch := range batches {
+		batchResults, batchFailed := s.importBatch(ctx, tx, batch)
+		failed += batchFailed
+		results = append(results, batchResults...)
+	}
+
+	if failed > 0 || dryRun {
+		markRolledBack(results, dryRun)
+		return results, false
+	}
+	tx.commit()
+
+	return results, true
+}
+
+// importBatchTx импортирует пачку в отдельной транзакции
+func (s *memorySource) importBatchTx(ctx context.Context, batch []*entity.UserImportRow, dryRun bool) []*entity.UserImportResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &memoryTx{}
+	defer tx.rollback()
+
+	results, _ := s.importBatch(ctx, tx, batch)
+	if dryRun {
+		markRolledBack(results, dryRun)
+		return results
+	}
+	tx.commit()
+
+	return results
+}
+
+// importBatch вставляет строки пачки. Строка вставляется целиком или не вставляется,
+// поэтому точки сохранения не нужны.
+func (s *memorySource) importBatch(ctx context.Context, tx *memoryTx, batch []*entity.UserImportRow) ([]*entity.UserImportResult, int) {
+	results := make([]*entity.UserImportResult, 0, len(batch))
+	failed := 0
+	for _, row := range batch {
+		result := &entity.UserImportResult{
+			Line: row.Line,
+		}
+		if row.User != nil {
+			result.Email = row.User.Email
+		}
+		results = append(results, result)
+
+		if row.Err != nil {
+			result.Status = entity.ImportStatusFailed
+			result.Error = row.Err.Error()
+			failed++
+			continue
+		}
+
+		userDB, err := s.insertUser(ctx, tx, row.User)
+		if err != nil {
+			result.Status = entity.ImportStatusFailed
+			result.Error = err.Error()
+			failed++
+			continue
+		}
+
+		result.ID = &entity.UserID{
+			Id: userDB.ID,
+		}
+		result.Status = entity.ImportStatusCreated
+	}
+
+	return results, failed
+}
+
+// insertUser создает пользователя и запись истории о создании. Вызывается под блокировкой записи.
+func (s *memorySource) insertUser(ctx context.Context, tx *memoryTx, user *entity.UserCreate) (*entity.UserDB, error) {
+	email := strings.ToLower(user.Email)
+	if _, ok := s.emails[email]; ok {
+		return nil, fmt.Errorf("can't exec query: %w", entity.ErrEmailTaken)
+	}
+
+	now := memoryNow()
+	userDB := &entity.UserDB{
+		ID:         uuid.New(),
+		FirstName:  user.FirstName,
+		SecondName: user.SecondName,
+		LastName:   user.LastName,
+		Password:   user.Password,
+		Age:        user.Age,
+		Email:      user.Email,
+		Phone:      user.Phone,
+		Role:       user.RoleOrDefault(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	s.users[userDB.ID] = userDB
+	s.emails[email] = userDB.ID
+	tx.onRollback(func() {
+		delete(s.users, userDB.ID)
+		delete(s.emails, email)
+	})
+
+	err := s.insertHistory(ctx, tx, entity.UserHistoryCreate, userDB.ID, nil, userDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneUser(userDB), nil
+}
+
+// insertHistory добавляет запись истории. Вызывается под блокировкой записи.
+// Исполнитель берется из контекста (entity.ActorFromContext).
+func (s *memorySource) insertHistory(ctx context.Context, tx *memoryTx, action entity.UserHistoryAction, userID uuid.UUID, before, after *entity.UserDB) error {
+	beforeJSON, err := memorySnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := memorySnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	var actorID uuid.NullUUID
+	if actor := entity.ActorFromContext(ctx); actor != nil {
+		actorID = uuid.NullUUID{UUID: actor.Id, Valid: true}
+	}
+
+	// Как и последовательность в PostgreSQL, id не переиспользуются после отката
+	s.historyID++
+	entry := &entity.UserHistoryDB{
+		ID:        s.historyID,
+		UserID:    userID,
+		Action:    string(action),
+		ActorID:   actorID,
+		Before:    beforeJSON,
+		After:     afterJSON,
+		CreatedAt: memoryNow(),
+	}
+	s.history = append(s.history, entry)
+	tx.onRollback(func() {
+		for i, e := range s.history {
+			if e == entry {
+				s.history = append(s.history[:i], s.history[i+1:]...)
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// memoryTx отменяет изменения memorySource, если транзакция не зафиксирована
+type memoryTx struct {
+	undo []func()
+}
+
+func (tx *memoryTx) onRollback(fn func()) {
+	tx.undo = append(tx.undo, fn)
+}
+
+func (tx *memoryTx) commit() {
+	tx.undo = nil
+}
+
+func (tx *memoryTx) rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.undo = nil
+}
+
+// matchUserFilter проверяет пользователя условиями buildUserFilter
+func matchUserFilter(filter *entity.UserFilter, user *entity.UserDB) bool {
+	contains := func(s, substr string) bool {
+		return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+	}
+
+	if filter.Email != "" && !contains(user.Email, filter.Email) {
+		return false
+	}
+	if filter.Name != "" && !contains(user.FirstName, filter.Name) && !contains(user.SecondName, filter.Name) && !contains(user.LastName, filter.Name) {
+		return false
+	}
+	if filter.Role != "" && user.Role != filter.Role {
+		return false
+	}
+	if filter.MinAge != nil && user.Age < *filter.MinAge {
+		return false
+	}
+	if filter.MaxAge != nil && user.Age > *filter.MaxAge {
+		return false
+	}
+
+	return true
+}
+
+// memorySnapshot возвращает снимок пользователя в виде JSON, как его хранит колонка jsonb, или nil
+func memorySnapshot(user *entity.UserDB) ([]byte, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity.NewUserSnapshot(user))
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal user snapshot: %w", err)
+	}
+
+	return data, nil
+}
+
+// eraseSnapshot затирает персональные данные снимка, как before || erasedSnapshot в PostgreSQL
+func eraseSnapshot(data []byte) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var snapshot, erased map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("can't unmarshal user snapshot: %w", err)
+	}
+	if err := json.Unmarshal([]byte(erasedSnapshot), &erased); err != nil {
+		return nil, fmt.Errorf("can't unmarshal erased snapshot: %w", err)
+	}
+	for key, value := range erased {
+		snapshot[key] = value
+	}
+
+	result, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal user snapshot: %w", err)
+	}
+
+	return result, nil
+}
+
+// memoryNow текущее время с точностью timestamptz
+func memoryNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func cloneUser(user *entity.UserDB) *entity.UserDB {
+	clone := *user
+	if user.ErasedAt != nil {
+		erasedAt := *user.ErasedAt
+		clone.ErasedAt = &erasedAt
+	}
+	if user.DisabledAt != nil {
+		disabledAt := *user.DisabledAt
+		clone.DisabledAt = &disabledAt
+	}
+
+	return &clone
+}
+
+func cloneHistory(entry *entity.UserHistoryDB) *entity.UserHistoryDB {
+	clone := *entry
+	clone.Before = bytes.Clone(entry.Before)
+	clone.After = bytes.Clone(entry.After)
+
+	return &clone
+}
@@ -64,6 +64,49 @@ func (mr *MockUserSourceMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserSource)(nil).DeleteUser), ctx, id)
 }
 
+// EraseUser mocks base method.
+func (m *MockUserSource) EraseUser(ctx context.Context, id *entity.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockUserSourceMockRecorder) EraseUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockUserSource)(nil).EraseUser), ctx, id)
+}
+
+// ExportUsers mocks base method.
+func (m *MockUserSource) ExportUsers(ctx context.Context, filter *entity.UserFilter, fn func(*entity.UserDB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockUserSourceMockRecorder) ExportUsers(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockUserSource)(nil).ExportUsers), ctx, filter, fn)
+}
+
+// GetUserAudit mocks base method.
+func (m *MockUserSource) GetUserAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAudit", ctx, id, cursor, limit)
+	ret0, _ := ret[0].([]*entity.UserHistoryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAudit indicates an expected call of GetUserAudit.
+func (mr *MockUserSourceMockRecorder) GetUserAudit(ctx, id, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAudit", reflect.TypeOf((*MockUserSource)(nil).GetUserAudit), ctx, id, cursor, limit)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserSource) GetUserByEmail(ctx context.Context, email string) (*entity.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +137,21 @@ func (mr *MockUserSourceMockRecorder) GetUserById(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserSource)(nil).GetUserById), ctx, id)
 }
 
+// GetUserHistory mocks base method.
+func (m *MockUserSource) GetUserHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) ([]*entity.UserHistoryDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHistory", ctx, id, cursor, limit)
+	ret0, _ := ret[0].([]*entity.UserHistoryDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHistory indicates an expected call of GetUserHistory.
+func (mr *MockUserSourceMockRecorder) GetUserHistory(ctx, id, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistory", reflect.TypeOf((*MockUserSource)(nil).GetUserHistory), ctx, id, cursor, limit)
+}
+
 // GetUserIdByEmail mocks base method.
 func (m *MockUserSource) GetUserIdByEmail(ctx context.Context, email string) (*entity.UserID, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +167,66 @@ func (mr *MockUserSourceMockRecorder) GetUserIdByEmail(ctx, email interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdByEmail", reflect.TypeOf((*MockUserSource)(nil).GetUserIdByEmail), ctx, email)
 }
 
+// ImportUsers mocks base method.
+func (m *MockUserSource) ImportUsers(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", ctx, opts, next)
+	ret0, _ := ret[0].(*entity.UserImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockUserSourceMockRecorder) ImportUsers(ctx, opts, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockUserSource)(nil).ImportUsers), ctx, opts, next)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockUserSource) SetUserDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", ctx, id, disabled)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockUserSourceMockRecorder) SetUserDisabled(ctx, id, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockUserSource)(nil).SetUserDisabled), ctx, id, disabled)
+}
+
+// SetUserPassword mocks base method.
+func (m *MockUserSource) SetUserPassword(ctx context.Context, id *entity.UserID, password string) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPassword", ctx, id, password)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserPassword indicates an expected call of SetUserPassword.
+func (mr *MockUserSourceMockRecorder) SetUserPassword(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPassword", reflect.TypeOf((*MockUserSource)(nil).SetUserPassword), ctx, id, password)
+}
+
+// SetUserRole mocks base method.
+func (m *MockUserSource) SetUserRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, id, role)
+	ret0, _ := ret[0].(*entity.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockUserSourceMockRecorder) SetUserRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockUserSource)(nil).SetUserRole), ctx, id, role)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserSource) UpdateUser(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -2,27 +2,40 @@ package db
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"go-test-grpc-http/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
 func (s *source) CreateUser(ctx context.Context, user *entity.UserCreate) (*entity.UserID, error) {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	newUuid := uuid.New()
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	row := s.db.QueryRowxContext(dbCtx, "INSERT INTO users (id, first_name, last_name, second_name, age, email, phone, password) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
-		newUuid, user.FirstName, user.LastName, user.SecondName, user.Age, user.Email, user.Phone, user.Password)
-	if row.Err() != nil {
-		return nil, fmt.Errorf("can't exec query: %w", row.Err())
+	userDB, err := s.insertUser(dbCtx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.insertHistory(dbCtx, tx, entity.UserHistoryCreate, userDB.ID, nil, userDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
 	}
 
 	return &entity.UserID{
-		Id: newUuid,
+		Id: userDB.ID,
 	}, nil
 }
 
@@ -32,15 +45,12 @@ func (s *source) GetUserById(ctx context.Context, id *entity.UserID) (*entity.Us
 
 	row := s.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE id = $1", id.String())
 	if row.Err() != nil {
-		return nil, fmt.Errorf("can't exec query: %w", row.Err())
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
 	}
 
 	var userDB entity.UserDB
 	if err := row.StructScan(&userDB); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
-		}
-		return nil, fmt.Errorf("can't scan user: %w", err)
+		return nil, fmt.Errorf("can't scan user: %w", translateError(err))
 	}
 
 	return &userDB, nil
@@ -50,17 +60,14 @@ func (s *source) GetUserByEmail(ctx context.Context, email string) (*entity.User
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := s.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE email = $1", email)
+	row := s.db.QueryRowxContext(dbCtx, "SELECT * FROM users WHERE lower(email) = lower($1)", email)
 	if row.Err() != nil {
-		return nil, fmt.Errorf("can't exec query: %w", row.Err())
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
 	}
 
 	var userDB entity.UserDB
 	if err := row.StructScan(&userDB); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
-		}
-		return nil, fmt.Errorf("can't scan user: %w", err)
+		return nil, fmt.Errorf("can't scan user: %w", translateError(err))
 	}
 
 	return &userDB, nil
@@ -70,17 +77,14 @@ func (s *source) GetUserIdByEmail(ctx context.Context, email string) (*entity.Us
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	row := s.db.QueryRowxContext(dbCtx, "SELECT id FROM users WHERE email = $1", email)
+	row := s.db.QueryRowxContext(dbCtx, "SELECT id FROM users WHERE lower(email) = lower($1)", email)
 	if row.Err() != nil {
-		return nil, fmt.Errorf("can't exec query: %w", row.Err())
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
 	}
 
 	var userId_str string
 	if err := row.Scan(&userId_str); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
-		}
-		return nil, fmt.Errorf("can't scan user id: %w", err)
+		return nil, fmt.Errorf("can't scan user id: %w", translateError(err))
 	}
 
 	userId := uuid.MustParse(userId_str)
@@ -94,48 +98,159 @@ func (s *source) UpdateUser(ctx context.Context, id *entity.UserID, user *entity
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	_, err := s.GetUserById(ctx, id)
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := s.getUserForUpdate(dbCtx, tx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, err
-		}
 		return nil, fmt.Errorf("can't get user: %w", err)
 	}
 
-	row := s.db.QueryRowxContext(dbCtx, "UPDATE users SET first_name = $1, last_name = $2, second_name = $3, age = $4, email = $5, phone = $6, password = $7 WHERE id = $8",
-		user.FirstName, user.LastName, user.SecondName, user.Age, user.Email, user.Phone, user.Password, id.String())
+	row := tx.QueryRowxContext(dbCtx, "UPDATE users SET first_name = $1, last_name = $2, second_name = $3, age = $4, email = $5, phone = $6, password = $7, updated_at = $8 WHERE id = $9 RETURNING *",
+		user.FirstName, user.LastName, user.SecondName, user.Age, user.Email, user.Phone, user.Password, time.Now().UTC(), id.String())
 	if row.Err() != nil {
-		return nil, fmt.Errorf("can't exec query: %w", row.Err())
-	}
-
-	return &entity.UserDB{
-		ID:         id.Id,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		SecondName: user.SecondName,
-		Age:        user.Age,
-		Email:      user.Email,
-		Phone:      user.Phone,
-		Password:   user.Password,
-	}, nil
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
+	}
+
+	var after entity.UserDB
+	if err := row.StructScan(&after); err != nil {
+		return nil, fmt.Errorf("can't scan user: %w", translateError(err))
+	}
+
+	err = s.insertHistory(dbCtx, tx, entity.UserHistoryUpdate, id.Id, before, &after)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return &after, nil
 }
 
 func (s *source) DeleteUser(ctx context.Context, id *entity.UserID) error {
 	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
 	defer dbCancel()
 
-	_, err := s.GetUserById(ctx, id)
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := s.getUserForUpdate(dbCtx, tx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return err
-		}
 		return fmt.Errorf("can't get user: %w", err)
 	}
 
-	row := s.db.QueryRowxContext(dbCtx, "DELETE FROM users WHERE id = $1", id.String())
-	if row.Err() != nil {
-		return fmt.Errorf("can't exec query: %w", row.Err())
+	_, err = tx.ExecContext(dbCtx, "DELETE FROM users WHERE id = $1", id.String())
+	if err != nil {
+		return fmt.Errorf("can't exec query: %w", err)
+	}
+
+	err = s.insertHistory(dbCtx, tx, entity.UserHistoryDelete, id.Id, before, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
 	}
 
 	return nil
 }
+
+// SetUserRole назначает пользователю роль
+func (s *source) SetUserRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.UserDB, error) {
+	return s.patchUser(ctx, id, "role = $3", role)
+}
+
+// SetUserDisabled блокирует пользователя или снимает блокировку
+func (s *source) SetUserDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.UserDB, error) {
+	if !disabled {
+		return s.patchUser(ctx, id, "disabled_at = NULL")
+	}
+	// Повторная блокировка сохраняет время первой
+	return s.patchUser(ctx, id, "disabled_at = COALESCE(disabled_at, $1)")
+}
+
+// SetUserPassword заменяет пароль пользователя
+func (s *source) SetUserPassword(ctx context.Context, id *entity.UserID, password string) (*entity.UserDB, error) {
+	return s.patchUser(ctx, id, "password = $3", password)
+}
+
+// patchUser изменяет поля пользователя выражением set и записывает изменение в историю.
+// В set доступны параметры $1 (текущее время) и $2 (id), значения args передаются начиная с $3.
+func (s *source) patchUser(ctx context.Context, id *entity.UserID, set string, args ...interface{}) (*entity.UserDB, error) {
+	dbCtx, dbCancel := context.WithTimeout(ctx, QueryTimeout)
+	defer dbCancel()
+
+	tx, err := s.db.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := s.getUserForUpdate(dbCtx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user: %w", err)
+	}
+
+	query := "UPDATE users SET " + set + ", updated_at = $1 WHERE id = $2 RETURNING *"
+	row := tx.QueryRowxContext(dbCtx, query, append([]interface{}{time.Now().UTC(), id.String()}, args...)...)
+	if row.Err() != nil {
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
+	}
+
+	var after entity.UserDB
+	if err := row.StructScan(&after); err != nil {
+		return nil, fmt.Errorf("can't scan user: %w", translateError(err))
+	}
+
+	err = s.insertHistory(dbCtx, tx, entity.UserHistoryUpdate, id.Id, before, &after)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return &after, nil
+}
+
+// insertUser создает пользователя в транзакции tx
+func (s *source) insertUser(ctx context.Context, tx *sqlx.Tx, user *entity.UserCreate) (*entity.UserDB, error) {
+	now := time.Now().UTC()
+	row := tx.QueryRowxContext(ctx, "INSERT INTO users (id, first_name, last_name, second_name, age, email, phone, password, role, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING *",
+		uuid.New(), user.FirstName, user.LastName, user.SecondName, user.Age, user.Email, user.Phone, user.Password, user.RoleOrDefault(), now)
+	if row.Err() != nil {
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
+	}
+
+	var userDB entity.UserDB
+	if err := row.StructScan(&userDB); err != nil {
+		return nil, fmt.Errorf("can't scan user: %w", translateError(err))
+	}
+
+	return &userDB, nil
+}
+
+// getUserForUpdate получает пользователя и блокирует его запись до конца транзакции tx
+func (s *source) getUserForUpdate(ctx context.Context, tx *sqlx.Tx, id *entity.UserID) (*entity.UserDB, error) {
+	row := tx.QueryRowxContext(ctx, "SELECT * FROM users WHERE id = $1 FOR UPDATE", id.String())
+	if row.Err() != nil {
+		return nil, fmt.Errorf("can't exec query: %w", translateError(row.Err()))
+	}
+
+	var userDB entity.UserDB
+	if err := row.StructScan(&userDB); err != nil {
+		return nil, fmt.Errorf("can't scan user: %w", translateError(err))
+	}
+
+	return &userDB, nil
+}
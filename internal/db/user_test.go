@@ -3,16 +3,59 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"go-test-grpc-http/internal/entity"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+const (
+	insertUserQuery    = "INSERT INTO users (id, first_name, last_name, second_name, age, email, phone, password, role, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING *"
+	selectForUpdate    = "SELECT * FROM users WHERE id = $1 FOR UPDATE"
+	updateUserQuery    = "UPDATE users SET first_name = $1, last_name = $2, second_name = $3, age = $4, email = $5, phone = $6, password = $7, updated_at = $8 WHERE id = $9 RETURNING *"
+	disableUserQuery   = "UPDATE users SET disabled_at = COALESCE(disabled_at, $1), updated_at = $1 WHERE id = $2 RETURNING *"
+	enableUserQuery    = "UPDATE users SET disabled_at = NULL, updated_at = $1 WHERE id = $2 RETURNING *"
+	insertHistoryQuery = "INSERT INTO user_history (user_id, action, actor_id, before, after, created_at) VALUES ($1, $2, $3, $4, $5, $6)"
+)
+
+func newUserRows(user *entity.UserDB) *sqlmock.Rows {
+	return sqlmock.
+		NewRows([]string{
+			"id",
+			"first_name",
+			"last_name",
+			"second_name",
+			"age",
+			"email",
+			"phone",
+			"password",
+			"role",
+			"created_at",
+			"updated_at",
+			"disabled_at",
+		}).
+		AddRow(
+			user.ID,
+			user.FirstName,
+			user.LastName,
+			user.SecondName,
+			user.Age,
+			user.Email,
+			user.Phone,
+			user.Password,
+			user.Role,
+			user.CreatedAt,
+			user.UpdatedAt,
+			user.DisabledAt,
+		)
+}
+
 func Test_source_GetUserById(t *testing.T) {
 	type fields struct {
 		db sqlmock.Sqlmock
@@ -152,90 +195,85 @@ func Test_source_CreateUser(t *testing.T) {
 		ctx  context.Context
 		user *entity.UserCreate
 	}
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	actor := &entity.UserID{Id: uuid.MustParse("0c2d4c1e-5d0f-4f63-9d56-0c1c1b0b6a11")}
+	user := &entity.UserCreate{
+		FirstName:  "John",
+		LastName:   "Doe",
+		SecondName: "DoeD",
+		Age:        30,
+		Email:      "doe@example.com",
+		Phone:      "+1111111111",
+		Password:   "qwerty1234",
+	}
+	created := &entity.UserDB{
+		ID:         id,
+		FirstName:  "John",
+		LastName:   "Doe",
+		SecondName: "DoeD",
+		Age:        30,
+		Email:      "doe@example.com",
+		Phone:      "+1111111111",
+		Password:   "qwerty1234",
+		Role:       entity.RoleUser,
+		CreatedAt:  time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
 	tests := []struct {
 		name    string
 		args    args
+		want    *entity.UserID
 		setup   func(a args, f fields)
 		wantErr bool
 	}{
 		{
 			name: "success: CreateUser source: user created",
 			args: args{
-				ctx: context.Background(),
-				user: &entity.UserCreate{
-					FirstName:  "John",
-					LastName:   "Doe",
-					SecondName: "DoeD",
-					Age:        30,
-					Email:      "doe@example.com",
-					Phone:      "+1111111111",
-					Password:   "qwerty1234",
-				},
+				ctx:  entity.ContextWithActor(context.Background(), actor),
+				user: user,
 			},
+			want: &entity.UserID{Id: id},
 			setup: func(a args, f fields) {
-				rows := sqlmock.
-					NewRows([]string{
-						"id",
-						"first_name",
-						"last_name",
-						"second_name",
-						"age",
-						"email",
-						"phone",
-						"password",
-					}).
-					AddRow(
-						uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-						"John",
-						"Doe",
-						"DoeD",
-						30,
-						"doe@example.com",
-						"+1111111111",
-						"qwerty1234",
-					)
-				f.db.ExpectQuery("INSERT INTO users (id, first_name, last_name, second_name, age, email, phone, password) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
-					WillReturnRows(rows)
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(insertUserQuery).
+					WithArgs(sqlmock.AnyArg(), "John", "Doe", "DoeD", 30, "doe@example.com", "+1111111111", "qwerty1234", entity.RoleUser, sqlmock.AnyArg()).
+					WillReturnRows(newUserRows(created))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "create", actor.Id.String(), nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				f.db.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
 			name: "error: CreateUser source: can't exec query",
 			args: args{
-				ctx: context.Background(),
-				user: &entity.UserCreate{
-					FirstName:  "John",
-					LastName:   "Doe",
-					SecondName: "DoeD",
-					Age:        30,
-					Email:      "doe@example.com",
-					Phone:      "+1111111111",
-					Password:   "qwerty1234",
-				},
+				ctx:  context.Background(),
+				user: user,
 			},
+			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("ABRA-CADABRA").
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(insertUserQuery).
 					WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectRollback()
 			},
 			wantErr: true,
 		},
 		{
-			name: "error: CreateUser source: can't scan user",
+			name: "error: CreateUser source: can't insert history",
 			args: args{
-				ctx: context.Background(),
-				user: &entity.UserCreate{
-					FirstName:  "John",
-					LastName:   "Doe",
-					SecondName: "DoeD",
-					Age:        30,
-					Email:      "doe@example.com",
-					Phone:      "+1111111111",
-					Password:   "qwerty1234",
-				},
+				ctx:  context.Background(),
+				user: user,
 			},
+			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("INSERT INTO users (id, first_name, last_name, second_name, age, email, phone, password) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)").
-					WillReturnError(fmt.Errorf("can't scan user"))
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(insertUserQuery).
+					WillReturnRows(newUserRows(created))
+				f.db.ExpectExec(insertHistoryQuery).
+					WillReturnError(fmt.Errorf("can't insert history"))
+				f.db.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -262,11 +300,11 @@ func Test_source_CreateUser(t *testing.T) {
 				t.Errorf("source.CreateUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != nil {
-				_, err = uuid.Parse(got.String())
-				if err != nil {
-					t.Errorf("source.CreateUser() = %v, want uuid", got)
-				}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("source.CreateUser() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("source.CreateUser() unmet expectations: %v", err)
 			}
 		})
 	}
@@ -281,117 +319,89 @@ func Test_source_UpdateUser(t *testing.T) {
 		id   *entity.UserID
 		user *entity.UserCreate
 	}
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	before := &entity.UserDB{
+		ID:         id,
+		FirstName:  "John",
+		LastName:   "Doe",
+		SecondName: "DoeD",
+		Age:        30,
+		Email:      "doe@example.com",
+		Phone:      "+1111111111",
+		Password:   "qwerty1234",
+		Role:       entity.RoleUser,
+		CreatedAt:  time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
+	after := *before
+	after.Age = 31
+	after.UpdatedAt = time.Date(2023, 8, 2, 12, 0, 0, 0, time.UTC)
+	user := &entity.UserCreate{
+		FirstName:  "John",
+		LastName:   "Doe",
+		SecondName: "DoeD",
+		Age:        31,
+		Email:      "doe@example.com",
+		Phone:      "+1111111111",
+		Password:   "qwerty1234",
+	}
 	tests := []struct {
 		name    string
 		args    args
 		want    *entity.UserDB
 		setup   func(a args, f fields)
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name: "success: Update source: user updated",
 			args: args{
-				ctx: context.Background(),
-				id: &entity.UserID{
-					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				},
-				user: &entity.UserCreate{
-					FirstName:  "John",
-					LastName:   "Doe",
-					SecondName: "DoeD",
-					Age:        31,
-					Email:      "doe@example.com",
-					Phone:      "+1111111111",
-					Password:   "qwerty1234",
-				},
-			},
-			want: &entity.UserDB{
-				ID:         uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				FirstName:  "John",
-				LastName:   "Doe",
-				SecondName: "DoeD",
-				Age:        31,
-				Email:      "doe@example.com",
-				Phone:      "+1111111111",
-				Password:   "qwerty1234",
+				ctx:  context.Background(),
+				id:   &entity.UserID{Id: id},
+				user: user,
 			},
+			want: &after,
 			setup: func(a args, f fields) {
-				rows := sqlmock.
-					NewRows([]string{
-						"id",
-						"first_name",
-						"last_name",
-						"second_name",
-						"age",
-						"email",
-						"phone",
-						"password",
-					}).
-					AddRow(
-						uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-						"John",
-						"Doe",
-						"DoeD",
-						31,
-						"doe@example.com",
-						"+1111111111",
-						"qwerty1234",
-					)
-				f.db.ExpectQuery("UPDATE users SET first_name = $1, last_name = $2, second_name = $3, age = $4, email = $5, phone = $6, password = $7 WHERE id = $8").
-					WithArgs("John", "Doe", "DoeD", 31, "doe@example.com", "+1111111111", "qwerty1234", uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
-					WillReturnRows(rows)
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(newUserRows(before))
+				f.db.ExpectQuery(updateUserQuery).
+					WithArgs("John", "Doe", "DoeD", 31, "doe@example.com", "+1111111111", "qwerty1234", sqlmock.AnyArg(), a.id.String()).
+					WillReturnRows(newUserRows(&after))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "update", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				f.db.ExpectCommit()
 			},
-			wantErr: false,
 		},
 		{
-			name: "error: Update source: can't exec query",
+			name: "error: Update source: user not found",
 			args: args{
-				ctx: context.Background(),
-				id: &entity.UserID{
-					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				},
-				user: &entity.UserCreate{
-					FirstName:  "John",
-					LastName:   "Doe",
-					SecondName: "DoeD",
-					Age:        30,
-					Email:      "doe@example.com",
-					Phone:      "+1111111111",
-					Password:   "qwerty1234",
-				},
+				ctx:  context.Background(),
+				id:   &entity.UserID{Id: id},
+				user: user,
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("ABRA-CADABRA").
-					WithArgs("John", "Doe", "DoeD", 31, "doe@example.com", "+1111111111", "qwerty1234", uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
-					WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectRollback()
 			},
-			wantErr: true,
+			wantErr: entity.ErrUserNotFound,
 		},
 		{
-			name: "error: Update source: can't scan user",
+			name: "error: Update source: can't exec query",
 			args: args{
-				ctx: context.Background(),
-				id: &entity.UserID{
-					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				},
-				user: &entity.UserCreate{
-					FirstName:  "John",
-					LastName:   "Doe",
-					SecondName: "DoeD",
-					Age:        30,
-					Email:      "doe@example.com",
-					Phone:      "+1111111111",
-					Password:   "qwerty1234",
-				},
+				ctx:  context.Background(),
+				id:   &entity.UserID{Id: id},
+				user: user,
 			},
 			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("UPDATE users SET first_name = $1, last_name = $2, second_name = $3, age = $4, email = $5, phone = $6, password = $7 WHERE id = $8").
-					WithArgs("John", "Doe", "DoeD", 31, "doe@example.com", "+1111111111", "qwerty1234", uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
-					WillReturnError(fmt.Errorf("can't scan user"))
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(newUserRows(before))
+				f.db.ExpectQuery(updateUserQuery).WillReturnError(errQuery)
+				f.db.ExpectRollback()
 			},
-			wantErr: true,
+			wantErr: errQuery,
 		},
 	}
 	for _, tt := range tests {
@@ -412,13 +422,16 @@ func Test_source_UpdateUser(t *testing.T) {
 			tt.setup(tt.args, f)
 
 			got, err := s.UpdateUser(tt.args.ctx, tt.args.id, tt.args.user)
-			if (err != nil) != tt.wantErr {
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("source.UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("source.UpdateUser() = %v, want %v", got, tt.want)
 			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("source.UpdateUser() unmet expectations: %v", err)
+			}
 		})
 	}
 }
@@ -431,61 +444,64 @@ func Test_source_DeleteUser(t *testing.T) {
 		ctx context.Context
 		id  *entity.UserID
 	}
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	before := &entity.UserDB{
+		ID:        id,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "doe@example.com",
+		Role:      entity.RoleUser,
+	}
 	tests := []struct {
 		name    string
 		args    args
-		want    *entity.UserDB
 		setup   func(a args, f fields)
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name: "success: Delete source: user deleted",
 			args: args{
 				ctx: context.Background(),
-				id: &entity.UserID{
-					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				},
+				id:  &entity.UserID{Id: id},
 			},
-			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("DELETE FROM users WHERE id = $1").
-					WithArgs(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
-					WillReturnRows(sqlmock.NewRows([]string{}))
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(newUserRows(before))
+				f.db.ExpectExec("DELETE FROM users WHERE id = $1").
+					WithArgs(a.id.String()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "delete", nil, sqlmock.AnyArg(), nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				f.db.ExpectCommit()
 			},
-			wantErr: false,
 		},
 		{
-			name: "error: DeleteUser source: can't exec query",
+			name: "error: DeleteUser source: user not found",
 			args: args{
 				ctx: context.Background(),
-				id: &entity.UserID{
-					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				},
+				id:  &entity.UserID{Id: id},
 			},
-			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("ABRA-CADABRA").
-					WithArgs(
-						uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
-					WillReturnError(fmt.Errorf("can't exec query"))
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectRollback()
 			},
-			wantErr: true,
+			wantErr: entity.ErrUserNotFound,
 		},
 		{
-			name: "error: UpDEleteUser source: can't scan user",
+			name: "error: DeleteUser source: can't exec query",
 			args: args{
 				ctx: context.Background(),
-				id: &entity.UserID{
-					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
-				},
+				id:  &entity.UserID{Id: id},
 			},
-			want: nil,
 			setup: func(a args, f fields) {
-				f.db.ExpectQuery("DELETE FROM users WHERE id = ?").
-					WithArgs(uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")).
-					WillReturnError(fmt.Errorf("can't scan user"))
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(a.id.String()).WillReturnRows(newUserRows(before))
+				f.db.ExpectExec("DELETE FROM users WHERE id = $1").WillReturnError(errQuery)
+				f.db.ExpectRollback()
 			},
-			wantErr: true,
+			wantErr: errQuery,
 		},
 	}
 	for _, tt := range tests {
@@ -505,9 +521,110 @@ func Test_source_DeleteUser(t *testing.T) {
 
 			tt.setup(tt.args, f)
 
-			if err := s.DeleteUser(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
+			if err := s.DeleteUser(tt.args.ctx, tt.args.id); !errors.Is(err, tt.wantErr) {
 				t.Errorf("source.DeleteUser() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("source.DeleteUser() unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+var errQuery = errors.New("can't exec query")
+
+func Test_source_SetUserDisabled(t *testing.T) {
+	type fields struct {
+		db sqlmock.Sqlmock
+	}
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	disabledAt := time.Date(2023, 8, 2, 12, 0, 0, 0, time.UTC)
+	active := &entity.UserDB{
+		ID:        id,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "doe@example.com",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
+	disabled := *active
+	disabled.DisabledAt = &disabledAt
+	disabled.UpdatedAt = disabledAt
+	tests := []struct {
+		name     string
+		disabled bool
+		want     *entity.UserDB
+		setup    func(f fields)
+		wantErr  error
+	}{
+		{
+			name:     "success: SetUserDisabled source: user disabled",
+			disabled: true,
+			want:     &disabled,
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(id.String()).WillReturnRows(newUserRows(active))
+				f.db.ExpectQuery(disableUserQuery).WithArgs(sqlmock.AnyArg(), id.String()).WillReturnRows(newUserRows(&disabled))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "update", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name:     "success: SetUserDisabled source: user enabled",
+			disabled: false,
+			want:     active,
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(id.String()).WillReturnRows(newUserRows(&disabled))
+				f.db.ExpectQuery(enableUserQuery).WithArgs(sqlmock.AnyArg(), id.String()).WillReturnRows(newUserRows(active))
+				f.db.ExpectExec(insertHistoryQuery).
+					WithArgs(id, "update", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				f.db.ExpectCommit()
+			},
+		},
+		{
+			name:     "error: SetUserDisabled source: user not found",
+			disabled: true,
+			setup: func(f fields) {
+				f.db.ExpectBegin()
+				f.db.ExpectQuery(selectForUpdate).WithArgs(id.String()).WillReturnError(sql.ErrNoRows)
+				f.db.ExpectRollback()
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Errorf("can't connect to database: %v", err)
+				return
+			}
+			f := fields{
+				db: mock,
+			}
+
+			s := &source{
+				db: sqlx.NewDb(db, "sqlmock"),
+			}
+
+			tt.setup(f)
+
+			got, err := s.SetUserDisabled(context.Background(), &entity.UserID{Id: id}, tt.disabled)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("source.SetUserDisabled() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("source.SetUserDisabled() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("source.SetUserDisabled() unmet expectations: %v", err)
+			}
 		})
 	}
 }
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Соответствие видов ошибок кодам транспортов
+var kinds = []struct {
+	err    error
+	reason string
+	http   int
+	grpc   codes.Code
+}{
+	{err: ErrNotFound, reason: "NOT_FOUND", http: http.StatusNotFound, grpc: codes.NotFound},
+	{err: ErrConflict, reason: "CONFLICT", http: http.StatusConflict, grpc: codes.AlreadyExists},
+	{err: ErrInvalid, reason: "INVALID", http: http.StatusUnprocessableEntity, grpc: codes.InvalidArgument},
+	{err: ErrForbidden, reason: "FORBIDDEN", http: http.StatusForbidden, grpc: codes.PermissionDenied},
+	{err: ErrUnauthenticated, reason: "UNAUTHENTICATED", http: http.StatusUnauthorized, grpc: codes.Unauthenticated},
+}
+
+// ReasonInternal причина для ошибок, не относящихся к предметной области
+const ReasonInternal = "INTERNAL"
+
+// Reason возвращает машиночитаемую причину ошибки, по умолчанию ReasonInternal
+func Reason(err error) string {
+	for _, kind := range kinds {
+		if errors.Is(err, kind.err) {
+			return kind.reason
+		}
+	}
+
+	return ReasonInternal
+}
+
+// HTTPStatus возвращает HTTP-статус для ошибки, по умолчанию 500
+func HTTPStatus(err error) int {
+	for _, kind := range kinds {
+		if errors.Is(err, kind.err) {
+			return kind.http
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// GRPCCode возвращает gRPC-код для ошибки, по умолчанию codes.Internal
+func GRPCCode(err error) codes.Code {
+	for _, kind := range kinds {
+		if errors.Is(err, kind.err) {
+			return kind.grpc
+		}
+	}
+
+	return codes.Internal
+}
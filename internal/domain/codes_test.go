@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantHTTP int
+		wantGRPC codes.Code
+	}{
+		{
+			name:     "not found",
+			err:      NotFound("user not found"),
+			wantHTTP: http.StatusNotFound,
+			wantGRPC: codes.NotFound,
+		},
+		{
+			name:     "wrapped conflict",
+			err:      fmt.Errorf("can't create user: %w", Conflict("email is already taken")),
+			wantHTTP: http.StatusConflict,
+			wantGRPC: codes.AlreadyExists,
+		},
+		{
+			name:     "invalid with details",
+			err:      fmt.Errorf("%w: unknown column", Invalid("invalid export options")),
+			wantHTTP: http.StatusUnprocessableEntity,
+			wantGRPC: codes.InvalidArgument,
+		},
+		{
+			name:     "forbidden",
+			err:      Forbidden("admin role is required"),
+			wantHTTP: http.StatusForbidden,
+			wantGRPC: codes.PermissionDenied,
+		},
+		{
+			name:     "unauthenticated",
+			err:      ErrUnauthenticated,
+			wantHTTP: http.StatusUnauthorized,
+			wantGRPC: codes.Unauthenticated,
+		},
+		{
+			name:     "internal",
+			err:      errors.New("connection refused"),
+			wantHTTP: http.StatusInternalServerError,
+			wantGRPC: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatus(tt.err); got != tt.wantHTTP {
+				t.Errorf("HTTPStatus() = %v, want %v", got, tt.wantHTTP)
+			}
+			if got := GRPCCode(tt.err); got != tt.wantGRPC {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.wantGRPC)
+			}
+			if got, want := IsKnown(tt.err), tt.wantGRPC != codes.Internal; got != want {
+				t.Errorf("IsKnown() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPublicMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "domain error",
+			err:  NotFound("user not found"),
+			want: "user not found",
+		},
+		{
+			name: "wrapped with layer prefixes",
+			err:  fmt.Errorf("can't get user: %w", fmt.Errorf("can't scan user: %w", NotFound("user not found"))),
+			want: "user not found",
+		},
+		{
+			name: "details are kept",
+			err:  fmt.Errorf("can't import users: %w", fmt.Errorf("%w: line 3: bad quote", Invalid("malformed import data"))),
+			want: "malformed import data: line 3: bad quote",
+		},
+		{
+			name: "internal error",
+			err:  fmt.Errorf("can't exec query: %w", errors.New("connection refused")),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PublicMessage(tt.err); got != tt.want {
+				t.Errorf("PublicMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
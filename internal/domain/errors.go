@@ -0,0 +1,129 @@
+// Package domain содержит ошибки предметной области, общие для всех слоев приложения.
+//
+//	Слои возвращают ошибки, обернутые через %w, а транспорты определяют код ответа
+//	по виду ошибки с помощью errors.Is (см. HTTPStatus и GRPCCode).
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Виды ошибок предметной области
+var (
+	ErrNotFound        = errors.New("not found")       // Объект не найден
+	ErrConflict        = errors.New("conflict")        // Состояние объекта не позволяет выполнить операцию
+	ErrInvalid         = errors.New("invalid")         // Некорректные входные данные
+	ErrForbidden       = errors.New("forbidden")       // Недостаточно прав
+	ErrUnauthenticated = errors.New("unauthenticated") // Пользователь не аутентифицирован
+)
+
+// Error ошибка предметной области определенного вида с сообщением, которое можно показать клиенту
+type Error struct {
+	kind       error
+	msg        string
+	violations []Violation
+}
+
+// Violation нарушение ограничения на конкретное поле входных данных
+type Violation struct {
+	Field       string // Путь к полю, например "email" или "user.age"
+	Description string // Описание нарушения
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Is позволяет проверять вид ошибки через errors.Is(err, domain.ErrNotFound)
+func (e *Error) Is(target error) bool {
+	return target == e.kind
+}
+
+// Kind возвращает вид ошибки
+func (e *Error) Kind() error {
+	return e.kind
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s", v.Field, v.Description)
+}
+
+// Violations возвращает нарушения ограничений полей, приложенные к ошибке
+func (e *Error) Violations() []Violation {
+	return e.violations
+}
+
+func NewError(kind error, msg string) *Error {
+	return &Error{
+		kind: kind,
+		msg:  msg,
+	}
+}
+
+func NotFound(msg string) *Error {
+	return NewError(ErrNotFound, msg)
+}
+
+func Conflict(msg string) *Error {
+	return NewError(ErrConflict, msg)
+}
+
+func Invalid(msg string) *Error {
+	return NewError(ErrInvalid, msg)
+}
+
+// InvalidFields создает ошибку некорректных входных данных с перечнем нарушений по полям
+func InvalidFields(msg string, violations ...Violation) *Error {
+	err := NewError(ErrInvalid, msg)
+	err.violations = violations
+	return err
+}
+
+func Forbidden(msg string) *Error {
+	return NewError(ErrForbidden, msg)
+}
+
+func Unauthenticated(msg string) *Error {
+	return NewError(ErrUnauthenticated, msg)
+}
+
+// IsKnown сообщает, относится ли ошибка к одному из видов ошибок предметной области.
+// Текст таких ошибок можно возвращать клиенту, остальные ошибки считаются внутренними.
+func IsKnown(err error) bool {
+	for _, kind := range kinds {
+		if errors.Is(err, kind.err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PublicMessage возвращает текст ошибки предметной области вместе с уточнениями, добавленными
+// через fmt.Errorf("%w: ...", err), но без префиксов слоев. Для внутренних ошибок возвращает пустую строку.
+func PublicMessage(err error) string {
+	var domainErr *Error
+	if !errors.As(err, &domainErr) {
+		return ""
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if strings.HasPrefix(e.Error(), domainErr.msg) {
+			return e.Error()
+		}
+	}
+
+	return domainErr.msg
+}
+
+// ViolationsOf возвращает нарушения ограничений полей из цепочки ошибки
+func ViolationsOf(err error) []Violation {
+	var domainErr *Error
+	if !errors.As(err, &domainErr) {
+		return nil
+	}
+
+	return domainErr.Violations()
+}
@@ -0,0 +1,25 @@
+package entity
+
+// UserAudience определяет, какие поля пользователя видит вызывающий
+type UserAudience string
+
+const (
+	UserAudienceSelf  UserAudience = "self"  // Собственный профиль: контактные данные и возраст
+	UserAudienceAdmin UserAudience = "admin" // Администратор: все поля, кроме пароля, включая роль и время удаления данных
+	UserAudienceOther UserAudience = "other" // Другой пользователь: только ID, ФИО и время создания
+)
+
+// UserAudienceOf возвращает аудиторию, для которой показывается user. viewer может быть nil, если
+// вызывающий не аутентифицирован. Пароль не показывается никому.
+func UserAudienceOf(viewer *User, user *User) UserAudience {
+	switch {
+	case viewer == nil:
+		return UserAudienceOther
+	case viewer.Role == RoleAdmin:
+		return UserAudienceAdmin
+	case viewer.ID != nil && user.ID != nil && viewer.ID.Id == user.ID.Id:
+		return UserAudienceSelf
+	default:
+		return UserAudienceOther
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// Формат файла импорта и экспорта пользователей
+type DataFormat string
+
+const (
+	DataFormatCSV    DataFormat = "csv"    // CSV с заголовком
+	DataFormatNDJSON DataFormat = "ndjson" // Один JSON-объект на строку
+)
+
+// Колонки, доступные для экспорта пользователей. Пароль не экспортируется никогда.
+var UserExportColumns = []string{
+	"id",
+	"first_name",
+	"second_name",
+	"last_name",
+	"age",
+	"email",
+	"phone",
+	"role",
+}
+
+// Фильтр списка пользователей
+type UserFilter struct {
+	Email  string // Подстрока электронной почты
+	Name   string // Подстрока имени, фамилии или отчества
+	Role   Role   // Роль
+	MinAge *int   // Минимальный возраст
+	MaxAge *int   // Максимальный возраст
+}
+
+// Параметры экспорта пользователей
+type UserExportOptions struct {
+	Format  DataFormat // Формат выходных данных
+	Columns []string   // Экспортируемые колонки, по умолчанию UserExportColumns
+	Filter  UserFilter // Фильтр пользователей
+}
+
+// Статус фоновой задачи экспорта
+type ExportJobStatus string
+
+const (
+	ExportJobStatusRunning ExportJobStatus = "running" // Выполняется
+	ExportJobStatusDone    ExportJobStatus = "done"    // Файл готов
+	ExportJobStatusFailed  ExportJobStatus = "failed"  // Завершилась ошибкой
+)
+
+// Фоновая задача экспорта пользователей
+type ExportJob struct {
+	ID         string             // ID задачи
+	Status     ExportJobStatus    // Статус
+	Options    *UserExportOptions // Параметры экспорта
+	Rows       int                // Количество выгруженных пользователей
+	Path       string             // Путь к файлу результата
+	Error      string             // Описание ошибки
+	CreatedAt  time.Time          // Время создания
+	FinishedAt time.Time          // Время завершения
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Архив всех данных, хранимых о пользователе
+type UserDataArchive struct {
+	ExportedAt time.Time           // Время формирования архива
+	Profile    *User               // Профиль
+	History    []*UserHistoryEntry // История изменений профиля
+	Audit      []*UserHistoryEntry // Действия пользователя над другими записями, без снимков данных
+	Sessions   []*UserSession      // Сеансы пользователя, всегда пусто: см. UserSession
+}
+
+// Сеанс пользователя. Токены доступа (JWT) не хранят состояния и нигде не записываются, поэтому
+// записей о сеансах нет. Раздел есть в архиве, чтобы явно показать, что таких данных не хранится.
+type UserSession struct {
+	ID        string    // ID токена
+	IssuedAt  time.Time // Время выдачи
+	ExpiresAt time.Time // Время окончания действия
+}
+
+// ErasedEmail возвращает адрес, которым заменяется email пользователя после удаления персональных данных.
+// Адрес уникален, чтобы не нарушать ограничения уникальности, и не может быть доставлен (домен .invalid).
+func ErasedEmail(id *UserID) string {
+	return "erased-" + id.String() + "@erased.invalid"
+}
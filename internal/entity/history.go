@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Действие над пользователем, записанное в историю
+type UserHistoryAction string
+
+const (
+	UserHistoryCreate UserHistoryAction = "create" // Создание
+	UserHistoryUpdate UserHistoryAction = "update" // Изменение
+	UserHistoryDelete UserHistoryAction = "delete" // Удаление
+	UserHistoryErase  UserHistoryAction = "erase"  // Удаление персональных данных
+)
+
+// Снимок данных пользователя в истории. Секреты (пароль) не сохраняются.
+type UserSnapshot struct {
+	FirstName  string     `json:"first_name"`            // Имя
+	SecondName string     `json:"second_name"`           // Отчество
+	LastName   string     `json:"last_name"`             // Фамилия
+	Age        int        `json:"age"`                   // Возраст
+	Email      string     `json:"email"`                 // Электронная почта
+	Phone      string     `json:"phone"`                 // Номер телефона
+	Role       Role       `json:"role"`                  // Роль
+	CreatedAt  time.Time  `json:"created_at"`            // Время создания
+	UpdatedAt  time.Time  `json:"updated_at"`            // Время изменения
+	DisabledAt *time.Time `json:"disabled_at,omitempty"` // Время блокировки
+}
+
+func NewUserSnapshot(user *UserDB) *UserSnapshot {
+	return &UserSnapshot{
+		FirstName:  user.FirstName,
+		SecondName: user.SecondName,
+		LastName:   user.LastName,
+		Age:        user.Age,
+		Email:      user.Email,
+		Phone:      user.Phone,
+		Role:       user.Role,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+		DisabledAt: user.DisabledAt,
+	}
+}
+
+// Представление записи истории пользователя в бд
+type UserHistoryDB struct {
+	ID        int64         `db:"id"`         // ID записи
+	UserID    uuid.UUID     `db:"user_id"`    // ID пользователя
+	Action    string        `db:"action"`     // Действие
+	ActorID   uuid.NullUUID `db:"actor_id"`   // ID пользователя, выполнившего действие
+	Before    []byte        `db:"before"`     // Снимок до изменения (JSON)
+	After     []byte        `db:"after"`      // Снимок после изменения (JSON)
+	CreatedAt time.Time     `db:"created_at"` // Время изменения
+}
+
+// Запись истории пользователя
+type UserHistoryEntry struct {
+	ID        int64             // ID записи
+	UserID    *UserID           // ID пользователя
+	Action    UserHistoryAction // Действие
+	ActorID   *UserID           // ID пользователя, выполнившего действие
+	Before    *UserSnapshot     // Снимок до изменения
+	After     *UserSnapshot     // Снимок после изменения
+	CreatedAt time.Time         // Время изменения
+}
+
+// Страница истории пользователя, записи отсортированы от новых к старым
+type UserHistoryPage struct {
+	Entries    []*UserHistoryEntry // Записи
+	NextCursor int64               // Курсор следующей страницы, 0 если страниц больше нет
+}
+
+type actorKey struct{}
+
+// ContextWithActor сохраняет в контексте ID пользователя, выполняющего действие
+func ContextWithActor(ctx context.Context, id *UserID) context.Context {
+	return context.WithValue(ctx, actorKey{}, id)
+}
+
+// ActorFromContext возвращает ID пользователя, выполняющего действие, или nil
+func ActorFromContext(ctx context.Context) *UserID {
+	id, _ := ctx.Value(actorKey{}).(*UserID)
+	return id
+}
@@ -0,0 +1,57 @@
+package entity
+
+// Поведение импорта при ошибках в отдельных строках
+type ImportMode string
+
+const (
+	ImportModeAllOrNothing ImportMode = "all-or-nothing" // Любая ошибка откатывает весь импорт
+	ImportModeBestEffort   ImportMode = "best-effort"    // Ошибочные строки пропускаются
+)
+
+// Статус строки импорта
+type ImportStatus string
+
+const (
+	ImportStatusCreated    ImportStatus = "created"     // Пользователь создан
+	ImportStatusValid      ImportStatus = "valid"       // Строка корректна (dry-run)
+	ImportStatusFailed     ImportStatus = "failed"      // Строка не импортирована из-за ошибки
+	ImportStatusRolledBack ImportStatus = "rolled_back" // Строка корректна, но импорт откатан
+)
+
+// Параметры импорта пользователей
+type UserImportOptions struct {
+	Format    DataFormat // Формат входных данных
+	Mode      ImportMode // Поведение при ошибках
+	DryRun    bool       // Проверить данные без сохранения
+	BatchSize int        // Количество строк в одной пачке
+}
+
+// Строка импорта пользователей
+type UserImportRow struct {
+	Line int         // Номер строки во входных данных
+	User *UserCreate // Данные пользователя
+	Err  error       // Ошибка разбора или валидации строки
+}
+
+// Результат импорта одной строки
+type UserImportResult struct {
+	Line   int          // Номер строки во входных данных
+	Email  string       // Электронная почта
+	ID     *UserID      // ID созданного пользователя
+	Status ImportStatus // Статус строки
+	Error  string       // Описание ошибки
+}
+
+// Отчет об импорте пользователей
+type UserImportReport struct {
+	Mode      ImportMode          // Поведение при ошибках
+	DryRun    bool                // Импорт выполнен без сохранения
+	Committed bool                // Изменения сохранены в бд
+	Total     int                 // Всего строк
+	Created   int                 // Создано пользователей
+	Failed    int                 // Строк с ошибками
+	Results   []*UserImportResult // Результаты по строкам
+}
+
+// UserImportBatchFunc возвращает очередную пачку строк импорта или io.EOF, если строк больше нет
+type UserImportBatchFunc func() ([]*UserImportRow, error)
@@ -21,12 +21,20 @@ func (t *Token) String() (string, error) {
 	return t.Token.SignedString([]byte(cfg.ApiKey))
 }
 
+// Срок действия токена, если параметры приложения недоступны
+const defaultTokenTTL = time.Hour * 24
+
 func GenerateToken(id *UserID) *Token {
+	ttl := defaultTokenTTL
+	if cfg, err := config.GetAppConfig(); err == nil {
+		ttl = cfg.Auth.TokenTTL
+	}
+
 	return &Token{
 		Token: jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
 			Id:        id.String(),
 			IssuedAt:  time.Now().Unix(),
-			ExpiresAt: time.Now().Add(time.Hour * 24).Unix(),
+			ExpiresAt: time.Now().Add(ttl).Unix(),
 			Subject:   "auth",
 		}),
 	}
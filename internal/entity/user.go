@@ -1,9 +1,25 @@
 package entity
 
 import (
+	"go-test-grpc-http/internal/domain"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+var (
+	ErrUserNotFound = domain.NotFound("user not found")         // Пользователь не найден
+	ErrEmailTaken   = domain.Conflict("email is already taken") // Email уже занят другим пользователем
+	ErrUserDisabled = domain.Forbidden("user is disabled")      // Пользователь заблокирован
+)
+
+// NormalizeEmail приводит email к каноничному виду: без пробелов по краям и в нижнем регистре.
+// Уникальность email в бд проверяется без учета регистра.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // Представление id пользователя
 type UserID struct {
 	Id uuid.UUID
@@ -19,27 +35,45 @@ func (u *UserID) FromString(s string) error {
 	return err
 }
 
+// Роль пользователя
+type Role string
+
+const (
+	RoleUser  Role = "user"  // Обычный пользователь
+	RoleAdmin Role = "admin" // Администратор
+)
+
 // Представление пользователя в бд
 type UserDB struct {
-	ID         uuid.UUID `db:"id"`          // ID
-	FirstName  string    `db:"first_name"`  // Имя
-	SecondName string    `db:"second_name"` // Отчество
-	LastName   string    `db:"last_name"`   // Фамилия
-	Password   string    `db:"password"`    // Пароль
-	Age        int       `db:"age"`         // Возраст
-	Email      string    `db:"email"`       // Электронная почта
-	Phone      string    `db:"phone"`       // Номер телефона
+	ID         uuid.UUID  `db:"id"`          // ID
+	FirstName  string     `db:"first_name"`  // Имя
+	SecondName string     `db:"second_name"` // Отчество
+	LastName   string     `db:"last_name"`   // Фамилия
+	Password   string     `db:"password"`    // Пароль
+	Age        int        `db:"age"`         // Возраст
+	Email      string     `db:"email"`       // Электронная почта
+	Phone      string     `db:"phone"`       // Номер телефона
+	Role       Role       `db:"role"`        // Роль
+	CreatedAt  time.Time  `db:"created_at"`  // Время создания
+	UpdatedAt  time.Time  `db:"updated_at"`  // Время изменения
+	ErasedAt   *time.Time `db:"erased_at"`   // Время удаления персональных данных
+	DisabledAt *time.Time `db:"disabled_at"` // Время блокировки
 }
 
 type User struct {
-	ID         *UserID // ID
-	FirstName  string  // Имя
-	SecondName string  // Отчество
-	LastName   string  // Фамилия
-	Password   string  // Пароль
-	Age        int     // Возраст
-	Email      string  // Электронная почта
-	Phone      string  // Номер телефона
+	ID         *UserID    // ID
+	FirstName  string     // Имя
+	SecondName string     // Отчество
+	LastName   string     // Фамилия
+	Password   string     // Пароль
+	Age        int        // Возраст
+	Email      string     // Электронная почта
+	Phone      string     // Номер телефона
+	Role       Role       // Роль
+	CreatedAt  time.Time  // Время создания
+	UpdatedAt  time.Time  // Время изменения
+	ErasedAt   *time.Time // Время удаления персональных данных
+	DisabledAt *time.Time // Время блокировки
 }
 
 // Представление пользователя для создания записи в бд
@@ -51,9 +85,20 @@ type UserCreate struct {
 	Age        int    // Возраст
 	Email      string // Электронная почта
 	Phone      string // Номер телефона
+	// Role роль нового пользователя, пустая - RoleUser. Задается только административной командой
+	// user create, API ее не принимает, а при обновлении она не меняется.
+	Role Role
+}
+
+// RoleOrDefault возвращает роль нового пользователя
+func (u *UserCreate) RoleOrDefault() Role {
+	if u.Role == "" {
+		return RoleUser
+	}
+	return u.Role
 }
 
-type UserSignIn struct {
-	Email    string // Электронная почта
-	Password string // Пароль
+// Normalize приводит данные пользователя к каноничному виду перед сохранением
+func (u *UserCreate) Normalize() {
+	u.Email = NormalizeEmail(u.Email)
 }
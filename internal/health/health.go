@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Состояния подсистем и приложения в целом
+const (
+	StatusServing    = "SERVING"
+	StatusNotServing = "NOT_SERVING"
+)
+
+// CheckTimeout ограничение времени одной проверки
+const CheckTimeout = 2 * time.Second
+
+// ShutdownCheck имя проверки, которая не проходит после начала остановки приложения
+const ShutdownCheck = "shutdown"
+
+var errShuttingDown = errors.New("application is shutting down")
+
+// CheckFunc проверяет готовность подсистемы, nil означает готовность
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult результат проверки подсистемы
+type CheckResult struct {
+	Status string
+	Error  string
+}
+
+// Report результат проверки готовности приложения
+type Report struct {
+	Status string
+	Checks map[string]CheckResult
+}
+
+// Registry проверки готовности подсистем приложения
+type Registry struct {
+	mu           sync.RWMutex
+	checks       map[string]CheckFunc
+	shuttingDown atomic.Bool
+}
+
+func NewRegistry() *Registry {
+	r := &Registry{
+		checks: make(map[string]CheckFunc),
+	}
+	r.Register(ShutdownCheck, func(context.Context) error {
+		if r.shuttingDown.Load() {
+			return errShuttingDown
+		}
+		return nil
+	})
+
+	return r
+}
+
+// Register добавляет проверку подсистемы name
+func (r *Registry) Register(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check
+}
+
+// Names возвращает имена зарегистрированных проверок в алфавитном порядке
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Shutdown переводит приложение в состояние NOT_SERVING. Вызывается в начале graceful shutdown.
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+}
+
+// Check выполняет проверку подсистемы name. ok равен false, если такой проверки нет.
+func (r *Registry) Check(ctx context.Context, name string) (result CheckResult, ok bool) {
+	r.mu.RLock()
+	check, ok := r.checks[name]
+	r.mu.RUnlock()
+	if !ok {
+		return CheckResult{}, false
+	}
+
+	return run(ctx, check), true
+}
+
+// Ready параллельно выполняет все проверки. Приложение готово, только если готовы все подсистемы.
+func (r *Registry) Ready(ctx context.Context) *Report {
+	r.mu.RLock()
+	checks := make(map[string]CheckFunc, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := &Report{
+		Status: StatusServing,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+			result := run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusServing {
+				report.Status = StatusNotServing
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func run(ctx context.Context, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	if err := check(ctx); err != nil {
+		return CheckResult{Status: StatusNotServing, Error: err.Error()}
+	}
+
+	return CheckResult{Status: StatusServing}
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]CheckFunc
+		shutdown   bool
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name: "all checks pass",
+			checks: map[string]CheckFunc{
+				"db": func(context.Context) error { return nil },
+			},
+			wantStatus: StatusServing,
+			wantChecks: map[string]string{"db": StatusServing, ShutdownCheck: StatusServing},
+		},
+		{
+			name: "failed check makes application not ready",
+			checks: map[string]CheckFunc{
+				"db":         func(context.Context) error { return nil },
+				"migrations": func(context.Context) error { return errors.New("dirty") },
+			},
+			wantStatus: StatusNotServing,
+			wantChecks: map[string]string{"db": StatusServing, "migrations": StatusNotServing, ShutdownCheck: StatusServing},
+		},
+		{
+			name: "shutdown makes application not ready",
+			checks: map[string]CheckFunc{
+				"db": func(context.Context) error { return nil },
+			},
+			shutdown:   true,
+			wantStatus: StatusNotServing,
+			wantChecks: map[string]string{"db": StatusServing, ShutdownCheck: StatusNotServing},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			for name, check := range tt.checks {
+				r.Register(name, check)
+			}
+			if tt.shutdown {
+				r.Shutdown()
+			}
+
+			report := r.Ready(context.Background())
+			if report.Status != tt.wantStatus {
+				t.Errorf("Ready() status = %v, want %v", report.Status, tt.wantStatus)
+			}
+			gotChecks := make(map[string]string, len(report.Checks))
+			for name, result := range report.Checks {
+				gotChecks[name] = result.Status
+			}
+			if !reflect.DeepEqual(gotChecks, tt.wantChecks) {
+				t.Errorf("Ready() checks = %v, want %v", gotChecks, tt.wantChecks)
+			}
+		})
+	}
+}
+
+func TestRegistry_Check(t *testing.T) {
+	r := NewRegistry()
+	r.Register("db", func(context.Context) error { return errors.New("connection refused") })
+
+	result, ok := r.Check(context.Background(), "db")
+	if !ok || result.Status != StatusNotServing || result.Error != "connection refused" {
+		t.Errorf("Check(db) = %+v, %v", result, ok)
+	}
+	if _, ok := r.Check(context.Background(), "unknown"); ok {
+		t.Errorf("Check(unknown) ok = true, want false")
+	}
+}
@@ -0,0 +1,255 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Этапы остановки приложения
+const (
+	PhaseServers   = "servers"
+	PhaseWorkers   = "workers"
+	PhaseResources = "resources"
+)
+
+// StopFunc останавливает компонент, не превышая срок ctx
+type StopFunc func(ctx context.Context) error
+
+// Component компонент приложения. Start и Stop необязательны.
+//
+//	Start ресурса или фоновой задачи должен вернуть управление после запуска,
+//	Start сервера обслуживает запросы и возвращает управление только после остановки сервера.
+type Component struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  StopFunc
+}
+
+// Result итог остановки компонента
+type Result struct {
+	Name     string
+	Phase    string
+	Duration time.Duration
+	Err      error
+}
+
+// Report итог остановки приложения
+type Report struct {
+	Results  []Result
+	Duration time.Duration
+}
+
+// Err объединяет ошибки остановки компонентов, nil - все компоненты остановлены штатно
+func (r *Report) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type entry struct {
+	Component
+	started bool
+}
+
+// Manager запускает и останавливает компоненты приложения.
+//
+//	Запуск: ресурсы и фоновые задачи в порядке добавления, затем серверы.
+//	Остановка: серверы параллельно дожидаются завершения текущих запросов,
+//	затем фоновые задачи и ресурсы в обратном порядке добавления.
+//
+// Каждый этап остановки ограничен сроком timeout. Останавливаются только запущенные компоненты,
+// компонент без Start считается запущенным с момента добавления.
+type Manager struct {
+	timeout time.Duration
+	logger  *zap.Logger
+
+	mu        sync.Mutex
+	servers   []*entry
+	workers   []*entry
+	resources []*entry
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewManager(timeout time.Duration, logger *zap.Logger) *Manager {
+	return &Manager{
+		timeout: timeout,
+		logger:  logger,
+		done:    make(chan struct{}),
+	}
+}
+
+// AddServer добавляет сервер, который останавливается параллельно с остальными серверами
+func (m *Manager) AddServer(c Component) {
+	m.add(&m.servers, c)
+}
+
+// AddWorker добавляет фоновую задачу, которая останавливается после серверов
+func (m *Manager) AddWorker(c Component) {
+	m.add(&m.workers, c)
+}
+
+// AddResource добавляет ресурс, который останавливается последним
+func (m *Manager) AddResource(c Component) {
+	m.add(&m.resources, c)
+}
+
+func (m *Manager) add(list *[]*entry, c Component) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*list = append(*list, &entry{Component: c, started: c.Start == nil})
+}
+
+// Start запускает ресурсы и фоновые задачи, затем серверы в отдельных горутинах.
+// При ошибке запуска уже запущенные компоненты останавливаются через Shutdown.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, group := range [][]*entry{m.resources, m.workers} {
+		for _, e := range group {
+			if e.started {
+				continue
+			}
+			err := e.Start(ctx)
+			if err != nil {
+				return fmt.Errorf("can't start %s: %w", e.Name, err)
+			}
+			e.started = true
+			m.logger.Info("component started", zap.String("component", e.Name))
+		}
+	}
+
+	for _, e := range m.servers {
+		if e.started {
+			continue
+		}
+		e.started = true
+		go m.serve(ctx, e)
+	}
+
+	return nil
+}
+
+// Done закрывается, когда любой из серверов завершил работу
+func (m *Manager) Done() <-chan struct{} {
+	return m.done
+}
+
+func (m *Manager) serve(ctx context.Context, e *entry) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Panic("server panic", zap.String("component", e.Name), zap.Error(fmt.Errorf("%s", r)))
+		}
+	}()
+	defer m.doneOnce.Do(func() {
+		close(m.done)
+	})
+
+	m.logger.Info("server started", zap.String("component", e.Name))
+	err := e.Start(ctx)
+	if err != nil {
+		m.logger.Error("server stopped with error", zap.String("component", e.Name), zap.Error(err))
+		return
+	}
+	m.logger.Info("server stopped", zap.String("component", e.Name))
+}
+
+// Shutdown останавливает запущенные компоненты и записывает в лог итог каждого
+func (m *Manager) Shutdown(ctx context.Context) *Report {
+	m.mu.Lock()
+	servers, workers, resources := started(m.servers), started(m.workers), started(m.resources)
+	m.mu.Unlock()
+
+	start := time.Now()
+	report := &Report{}
+	report.Results = append(report.Results, m.parallel(ctx, PhaseServers, servers)...)
+	report.Results = append(report.Results, m.reverse(ctx, PhaseWorkers, workers)...)
+	report.Results = append(report.Results, m.reverse(ctx, PhaseResources, resources)...)
+	report.Duration = time.Since(start)
+
+	if err := report.Err(); err != nil {
+		m.logger.Error("application stopped with errors", zap.Duration("duration", report.Duration), zap.Error(err))
+	} else {
+		m.logger.Info("application stopped", zap.Duration("duration", report.Duration))
+	}
+
+	return report
+}
+
+// started возвращает запущенные компоненты, у которых есть Stop
+func started(entries []*entry) []Component {
+	components := make([]Component, 0, len(entries))
+	for _, e := range entries {
+		if e.started && e.Stop != nil {
+			components = append(components, e.Component)
+		}
+	}
+
+	return components
+}
+
+func (m *Manager) parallel(ctx context.Context, phase string, components []Component) []Result {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	results := make([]Result, len(components))
+	wg := sync.WaitGroup{}
+	for i, c := range components {
+		wg.Add(1)
+		go func(i int, c Component) {
+			defer wg.Done()
+			results[i] = m.stop(ctx, phase, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (m *Manager) reverse(ctx context.Context, phase string, components []Component) []Result {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	results := make([]Result, 0, len(components))
+	for i := len(components) - 1; i >= 0; i-- {
+		results = append(results, m.stop(ctx, phase, components[i]))
+	}
+
+	return results
+}
+
+func (m *Manager) stop(ctx context.Context, phase string, c Component) Result {
+	start := time.Now()
+	err := c.Stop(ctx)
+	result := Result{
+		Name:     c.Name,
+		Phase:    phase,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+
+	fields := []zap.Field{
+		zap.String("component", result.Name),
+		zap.String("phase", result.Phase),
+		zap.Duration("duration", result.Duration),
+	}
+	if err != nil {
+		m.logger.Error("component stop error", append(fields, zap.Error(err))...)
+	} else {
+		m.logger.Info("component stopped", fields...)
+	}
+
+	return result
+}
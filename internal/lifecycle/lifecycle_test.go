@@ -0,0 +1,161 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recorder записывает порядок вызовов
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func TestManager_Start(t *testing.T) {
+	m := NewManager(time.Second, zap.NewNop())
+	rec := &recorder{}
+
+	m.AddResource(Component{
+		Name:  "db",
+		Start: func(context.Context) error { rec.record("start db"); return nil },
+		Stop:  func(context.Context) error { rec.record("stop db"); return nil },
+	})
+	m.AddWorker(Component{
+		Name:  "export jobs",
+		Start: func(context.Context) error { rec.record("start export jobs"); return errors.New("no space left") },
+		Stop:  func(context.Context) error { rec.record("stop export jobs"); return nil },
+	})
+	m.AddServer(Component{
+		Name:  "http",
+		Start: func(context.Context) error { rec.record("start http"); return nil },
+		Stop:  func(context.Context) error { rec.record("stop http"); return nil },
+	})
+
+	if err := m.Start(context.Background()); err == nil {
+		t.Fatalf("Start() error = nil, want error")
+	}
+	// Останавливается только то, что успело запуститься
+	m.Shutdown(context.Background())
+
+	want := []string{"start db", "start export jobs", "stop db"}
+	if got := rec.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestManager_Done(t *testing.T) {
+	m := NewManager(time.Second, zap.NewNop())
+
+	stopped := make(chan struct{})
+	m.AddServer(Component{
+		Name: "http",
+		Start: func(context.Context) error {
+			<-stopped
+			return nil
+		},
+		Stop: func(context.Context) error {
+			close(stopped)
+			return nil
+		},
+	})
+	m.AddServer(Component{
+		Name:  "grpc",
+		Start: func(context.Context) error { return errors.New("address already in use") },
+	})
+
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	select {
+	case <-m.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("Done() is not closed after server stop")
+	}
+	if err := m.Shutdown(context.Background()).Err(); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	m := NewManager(50*time.Millisecond, zap.NewNop())
+	rec := &recorder{}
+
+	m.AddResource(Component{
+		Name: "db",
+		Stop: func(context.Context) error { rec.record("db"); return nil },
+	})
+	m.AddResource(Component{
+		Name: "tracing",
+		Stop: func(context.Context) error { rec.record("tracing"); return nil },
+	})
+	m.AddWorker(Component{
+		Name: "export jobs",
+		Stop: func(ctx context.Context) error {
+			// Срок этапа отсчитывается заново
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rec.record("export jobs")
+			return nil
+		},
+	})
+	// Серверы останавливаются параллельно: каждый ждет, пока начнет остановку другой
+	httpStarted, grpcStarted := make(chan struct{}), make(chan struct{})
+	m.AddServer(Component{
+		Name: "http",
+		Stop: func(ctx context.Context) error {
+			close(httpStarted)
+			<-grpcStarted
+			rec.record("http")
+			return nil
+		},
+	})
+	m.AddServer(Component{
+		Name: "grpc",
+		Stop: func(ctx context.Context) error {
+			close(grpcStarted)
+			<-httpStarted
+			// Сервер не успевает завершить запросы до срока
+			<-ctx.Done()
+			rec.record("grpc")
+			return ctx.Err()
+		},
+	})
+
+	report := m.Shutdown(context.Background())
+
+	wantOrder := []string{"http", "grpc", "export jobs", "tracing", "db"}
+	if got := rec.get(); !reflect.DeepEqual(got, wantOrder) {
+		t.Errorf("stop order = %v, want %v", got, wantOrder)
+	}
+	if len(report.Results) != len(wantOrder) {
+		t.Fatalf("Shutdown() results = %d, want %d", len(report.Results), len(wantOrder))
+	}
+	for _, result := range report.Results {
+		wantErr := result.Name == "grpc"
+		if (result.Err != nil) != wantErr {
+			t.Errorf("result %s error = %v, wantErr %v", result.Name, result.Err, wantErr)
+		}
+	}
+	if err := report.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Report.Err() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
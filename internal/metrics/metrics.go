@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Пространство имен метрик приложения
+const namespace = "users"
+
+// Метрики HTTP-сервера
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Количество обработанных HTTP-запросов.",
+	}, []string{"method", "route", "status"})
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки HTTP-запросов.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Метрики gRPC-сервера
+var (
+	GRPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Количество обработанных gRPC-вызовов.",
+	}, []string{"method", "code"})
+	GRPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки gRPC-вызовов.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// Метрики запросов к бд
+var (
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Время выполнения запросов к бд по методам источника данных.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"query", "status"})
+)
+
+// Бизнес-метрики
+var (
+	SignUps = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ups_total",
+		Help:      "Количество успешных регистраций.",
+	})
+	SignIns = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ins_total",
+		Help:      "Количество успешных входов.",
+	})
+	FailedLogins = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "failed_logins_total",
+		Help:      "Количество неудачных попыток входа.",
+	})
+)
+
+// Метрики конфигурации и ограничения частоты запросов
+var (
+	ConfigReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "reloads_total",
+		Help:      "Количество перечитываний конфигурации по результату: applied, unchanged, failed.",
+	}, []string{"result"})
+	ConfigReloadRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "reload_rejected_total",
+		Help:      "Количество отклоненных изменений параметров, требующих перезапуска.",
+	}, []string{"field"})
+	ConfigLastReload = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "last_reload_timestamp_seconds",
+		Help:      "Время последнего применения изменений конфигурации.",
+	})
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Количество запросов, отклоненных ограничением частоты.",
+	}, []string{"transport"})
+)
+
+// Значения метки result перечитывания конфигурации
+const (
+	ReloadApplied   = "applied"
+	ReloadUnchanged = "unchanged"
+	ReloadFailed    = "failed"
+)
+
+// Значения метки status для запросов к бд
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// ObserveQuery учитывает время выполнения запроса query к бд, начатого в start
+func ObserveQuery(query string, start time.Time, err error) {
+	status := StatusOK
+	if err != nil {
+		status = StatusError
+	}
+	DBQueryDuration.WithLabelValues(query, status).Observe(time.Since(start).Seconds())
+}
+
+// RegisterDBStats регистрирует метрики пула соединений с бд
+func RegisterDBStats(db *sqlx.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db.DB, name))
+}
+
+// Handler возвращает обработчик /metrics в текстовом формате Prometheus
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"go-test-grpc-http/internal/entity"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Как часто удаляются ограничители клиентов, которые давно не обращались
+const sweepInterval = time.Minute
+
+// Settings возвращает текущие параметры ограничения: число запросов в секунду (0 - без ограничения) и допустимый всплеск
+type Settings func() (rps float64, burst int)
+
+// Limiter ограничитель частоты запросов для HTTP и gRPC. У каждого клиента свой запас запросов,
+// поэтому частые запросы одного клиента не мешают остальным.
+// Параметры читаются при каждом запросе, поэтому их изменение применяется без перезапуска.
+type Limiter struct {
+	settings Settings
+
+	mu    sync.Mutex
+	rps   float64
+	burst int
+	// clients ограничители по ключу клиента, см. ClientKey
+	clients   map[string]*client
+	lastSweep time.Time
+}
+
+type client struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewLimiter(settings Settings) *Limiter {
+	return &Limiter{
+		settings: settings,
+		clients:  make(map[string]*client),
+	}
+}
+
+// Allow сообщает, можно ли обработать запрос клиента key сейчас
+func (l *Limiter) Allow(key string) bool {
+	rps, burst := l.settings()
+	if rps <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	// При изменении параметров все клиенты начинают с полного запаса запросов
+	if l.rps != rps || l.burst != burst {
+		l.rps, l.burst = rps, burst
+		l.clients = make(map[string]*client)
+	}
+	l.sweep(now)
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+
+	return c.limiter.AllowN(now, 1)
+}
+
+// sweep удаляет клиентов, чей запас запросов уже восстановился полностью: новый ограничитель для них
+// ведет себя так же
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(float64(l.burst) / l.rps * float64(time.Second))
+	for key, c := range l.clients {
+		if now.Sub(c.lastSeen) > refill {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// ClientKey возвращает ключ клиента для Allow: ID пользователя, если authorization содержит действительный
+// токен, иначе адрес addr. Ограничение проверяется до аутентификации, поэтому токен разбирается здесь.
+func ClientKey(authorization string, addr string) string {
+	if token := strings.TrimPrefix(authorization, "Bearer "); token != "" {
+		if id, err := entity.ParseToken(token); err == nil {
+			return "user:" + id.String()
+		}
+	}
+
+	return "addr:" + addr
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	rps, burst := 0.0, 0
+	l := NewLimiter(func() (float64, int) { return rps, burst })
+
+	for i := 0; i < 10; i++ {
+		if !l.Allow("client") {
+			t.Fatalf("Allow() = false without limit")
+		}
+	}
+
+	// Новые параметры применяются к следующему запросу
+	rps, burst = 0.001, 2
+	for i := 0; i < 2; i++ {
+		if !l.Allow("client") {
+			t.Fatalf("Allow() = false within burst, request %d", i)
+		}
+	}
+	if l.Allow("client") {
+		t.Errorf("Allow() = true over burst")
+	}
+
+	rps = 0
+	if !l.Allow("client") {
+		t.Errorf("Allow() = false after limit is disabled")
+	}
+}
+
+func TestLimiter_Allow_perClient(t *testing.T) {
+	l := NewLimiter(func() (float64, int) { return 0.001, 1 })
+
+	if !l.Allow("noisy") {
+		t.Fatalf("Allow(noisy) = false within burst")
+	}
+	if l.Allow("noisy") {
+		t.Fatalf("Allow(noisy) = true over burst")
+	}
+	// Исчерпанный запас одного клиента не влияет на других
+	if !l.Allow("quiet") {
+		t.Errorf("Allow(quiet) = false after another client hit the limit")
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	var cfg config.Config
+	if err := config.Parse(config.NewParser(&cfg), &cfg, nil); err != nil {
+		t.Fatalf("can't parse config: %v", err)
+	}
+	config.SetAppConfig(&cfg)
+
+	id := &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}
+	token, err := entity.GenerateToken(id).String()
+	if err != nil {
+		t.Fatalf("can't generate token: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          string
+	}{
+		{name: "valid token", authorization: "Bearer " + token, want: "user:" + id.String()},
+		{name: "invalid token", authorization: "Bearer invalid", want: "addr:192.0.2.1"},
+		{name: "no token", want: "addr:192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClientKey(tt.authorization, "192.0.2.1"); got != tt.want {
+				t.Errorf("ClientKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"go-test-grpc-http/internal/metrics"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Задержка перед перечитыванием: редакторы и ConfigMap меняют файл несколькими событиями подряд
+const debounce = 200 * time.Millisecond
+
+// Watcher перечитывает конфигурацию при изменении файла конфигурации или по SIGHUP
+// и применяет параметры, допустимые без перезапуска. Изменения остальных параметров отклоняются.
+type Watcher struct {
+	path   string
+	level  zap.AtomicLevel
+	logger *zap.Logger
+	// load и apply заменяются в тестах
+	load  func() (*config.Config, error)
+	apply func(next *config.Config) (applied, rejected []string)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWatcher создает наблюдателя за файлом path (пустой путь - только SIGHUP).
+// level - уровень логирования, который меняется при изменении log-level.
+func NewWatcher(path string, level zap.AtomicLevel, logger *zap.Logger) *Watcher {
+	return &Watcher{
+		path:   path,
+		level:  level,
+		logger: logger,
+		load:   config.Reload,
+		apply:  config.Apply,
+	}
+}
+
+// Start запускает наблюдение в фоне
+func (w *Watcher) Start(_ context.Context) error {
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	var fsWatcher *fsnotify.Watcher
+	if w.path != "" {
+		var err error
+		fsWatcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("can't create config file watcher: %w", err)
+		}
+		// Следим за каталогом: файл могут заменить переименованием или сменой символической ссылки
+		err = fsWatcher.Add(filepath.Dir(w.path))
+		if err != nil {
+			fsWatcher.Close()
+			return fmt.Errorf("can't watch config file: %w", err)
+		}
+		events, errs = fsWatcher.Events, fsWatcher.Errors
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer signal.Stop(hangup)
+		if fsWatcher != nil {
+			defer fsWatcher.Close()
+		}
+
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-hangup:
+				w.Reload("sighup")
+			case event := <-events:
+				if w.affects(event) {
+					timer.Reset(debounce)
+				}
+			case <-timer.C:
+				w.Reload("file")
+			case err := <-errs:
+				w.logger.Error("config file watcher error", zap.Error(err))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop прекращает наблюдение
+func (w *Watcher) Stop(_ context.Context) error {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	return nil
+}
+
+// Reload перечитывает конфигурацию и применяет допустимые изменения. trigger - причина для лога.
+func (w *Watcher) Reload(trigger string) {
+	logger := w.logger.With(zap.String("trigger", trigger))
+
+	next, err := w.load()
+	if err != nil {
+		metrics.ConfigReloads.WithLabelValues(metrics.ReloadFailed).Inc()
+		logger.Error("can't reload config, keeping current", zap.Error(err))
+		return
+	}
+	level, err := zapcore.ParseLevel(next.LogLevel)
+	if err != nil {
+		metrics.ConfigReloads.WithLabelValues(metrics.ReloadFailed).Inc()
+		logger.Error("can't reload config, keeping current", zap.Error(err))
+		return
+	}
+
+	applied, rejected := w.apply(next)
+	for _, field := range rejected {
+		metrics.ConfigReloadRejected.WithLabelValues(field).Inc()
+	}
+	if len(rejected) > 0 {
+		logger.Warn("config changes require restart and are ignored", zap.Strings("fields", rejected))
+	}
+	if len(applied) == 0 {
+		metrics.ConfigReloads.WithLabelValues(metrics.ReloadUnchanged).Inc()
+		logger.Info("config reloaded without changes")
+		return
+	}
+
+	w.level.SetLevel(level)
+	metrics.ConfigReloads.WithLabelValues(metrics.ReloadApplied).Inc()
+	metrics.ConfigLastReload.SetToCurrentTime()
+	logger.Info("config reloaded", zap.Strings("fields", applied))
+}
+
+// affects сообщает, затрагивает ли событие файл конфигурации
+func (w *Watcher) affects(event fsnotify.Event) bool {
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+		return false
+	}
+	if filepath.Clean(event.Name) == filepath.Clean(w.path) {
+		return true
+	}
+	// Kubernetes ConfigMap обновляется заменой ссылки ..data в каталоге
+	return filepath.Base(event.Name) == "..data"
+}
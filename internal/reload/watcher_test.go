@@ -0,0 +1,81 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"go-test-grpc-http/cmd/go-test-grpc-http/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWatcher_Reload(t *testing.T) {
+	tests := []struct {
+		name      string
+		load      func() (*config.Config, error)
+		applied   []string
+		wantLevel zapcore.Level
+	}{
+		{
+			name:      "safe change is applied",
+			load:      func() (*config.Config, error) { return &config.Config{LogLevel: "debug"}, nil },
+			applied:   []string{"log-level"},
+			wantLevel: zapcore.DebugLevel,
+		},
+		{
+			name:      "only unsafe changes",
+			load:      func() (*config.Config, error) { return &config.Config{LogLevel: "debug"}, nil },
+			wantLevel: zapcore.InfoLevel,
+		},
+		{
+			name:      "invalid config keeps current",
+			load:      func() (*config.Config, error) { return nil, errors.New("invalid config") },
+			wantLevel: zapcore.InfoLevel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+			w := NewWatcher("", level, zap.NewNop())
+			w.load = tt.load
+			w.apply = func(*config.Config) ([]string, []string) { return tt.applied, []string{"db_host"} }
+
+			w.Reload("test")
+
+			if level.Level() != tt.wantLevel {
+				t.Errorf("level = %v, want %v", level.Level(), tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestWatcher_fileChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("log-level: info\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	reloaded := make(chan struct{}, 1)
+	w := NewWatcher(path, zap.NewAtomicLevel(), zap.NewNop())
+	w.load = func() (*config.Config, error) {
+		reloaded <- struct{}{}
+		return nil, errors.New("stop")
+	}
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer w.Stop(context.Background())
+
+	if err := os.WriteFile(path, []byte("log-level: debug\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("config is not reloaded after file change")
+	}
+}
@@ -14,4 +14,12 @@ type UserRepository interface {
 	GetIdByEmail(ctx context.Context, email string) (*entity.UserID, error)
 	Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.User, error)
 	Delete(ctx context.Context, id *entity.UserID) error
+	SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.User, error)
+	SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.User, error)
+	SetPassword(ctx context.Context, id *entity.UserID, password string) (*entity.User, error)
+	GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error)
+	GetAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error)
+	Erase(ctx context.Context, id *entity.UserID) error
+	Export(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.User) error) error
+	Import(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error)
 }
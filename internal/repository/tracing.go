@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/tracing"
+)
+
+// tracedRepository создает спан UserRepository.<метод> для каждого вызова репозитория
+type tracedRepository struct {
+	next UserRepository
+}
+
+// Trace оборачивает репозиторий трассировкой каждого метода
+func Trace(next UserRepository) UserRepository {
+	return &tracedRepository{
+		next: next,
+	}
+}
+
+func (t *tracedRepository) Create(ctx context.Context, user *entity.UserCreate) (res *entity.UserID, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Create")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Create(ctx, user)
+}
+
+func (t *tracedRepository) GetById(ctx context.Context, id *entity.UserID) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetById")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetById(ctx, id)
+}
+
+func (t *tracedRepository) GetByEmail(ctx context.Context, email string) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetByEmail")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetByEmail(ctx, email)
+}
+
+func (t *tracedRepository) GetIdByEmail(ctx context.Context, email string) (res *entity.UserID, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetIdByEmail")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetIdByEmail(ctx, email)
+}
+
+func (t *tracedRepository) Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Update")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Update(ctx, id, user)
+}
+
+func (t *tracedRepository) Delete(ctx context.Context, id *entity.UserID) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Delete(ctx, id)
+}
+
+func (t *tracedRepository) SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.SetRole")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SetRole(ctx, id, role)
+}
+
+func (t *tracedRepository) SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.SetDisabled")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SetDisabled(ctx, id, disabled)
+}
+
+func (t *tracedRepository) SetPassword(ctx context.Context, id *entity.UserID, password string) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.SetPassword")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SetPassword(ctx, id, password)
+}
+
+func (t *tracedRepository) GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (res *entity.UserHistoryPage, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetHistory")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetHistory(ctx, id, cursor, limit)
+}
+
+func (t *tracedRepository) GetAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) (res *entity.UserHistoryPage, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.GetAudit")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetAudit(ctx, id, cursor, limit)
+}
+
+func (t *tracedRepository) Erase(ctx context.Context, id *entity.UserID) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Erase")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Erase(ctx, id)
+}
+
+func (t *tracedRepository) Export(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.User) error) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Export")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Export(ctx, filter, fn)
+}
+
+func (t *tracedRepository) Import(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (res *entity.UserImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Import")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Import(ctx, opts, next)
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
 	"fmt"
 	"go-test-grpc-http/internal/db"
 	"go-test-grpc-http/internal/entity"
@@ -30,55 +30,24 @@ func (u *userRepository) Create(ctx context.Context, user *entity.UserCreate) (*
 func (u *userRepository) GetById(ctx context.Context, id *entity.UserID) (*entity.User, error) {
 	user, err := u.source.GetUserById(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, fmt.Errorf("can't get user by id from db: %w", err)
 	}
 
-	return &entity.User{
-		ID: &entity.UserID{
-			Id: user.ID,
-		},
-		FirstName:  user.FirstName,
-		SecondName: user.SecondName,
-		LastName:   user.LastName,
-		Password:   user.Password,
-		Age:        user.Age,
-		Email:      user.Email,
-		Phone:      user.Phone,
-	}, nil
+	return toUser(user), nil
 }
 
 func (u *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	user, err := u.source.GetUserByEmail(ctx, email)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, fmt.Errorf("can't get user by email from db: %w", err)
 	}
 
-	return &entity.User{
-		ID: &entity.UserID{
-			Id: user.ID,
-		},
-		FirstName:  user.FirstName,
-		SecondName: user.SecondName,
-		LastName:   user.LastName,
-		Password:   user.Password,
-		Age:        user.Age,
-		Email:      user.Email,
-		Phone:      user.Phone,
-	}, nil
+	return toUser(user), nil
 }
 
 func (u *userRepository) GetIdByEmail(ctx context.Context, email string) (*entity.UserID, error) {
 	id, err := u.source.GetUserIdByEmail(ctx, email)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, fmt.Errorf("can't get user id by email from db: %w", err)
 	}
 
@@ -88,34 +57,168 @@ func (u *userRepository) GetIdByEmail(ctx context.Context, email string) (*entit
 func (u *userRepository) Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.User, error) {
 	dbUser, err := u.source.UpdateUser(ctx, id, user)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("can't to update user: %w", err)
+		return nil, fmt.Errorf("can't update user in db: %w", err)
 	}
 
-	return &entity.User{
-		ID: &entity.UserID{
-			Id: dbUser.ID,
-		},
-		FirstName:  dbUser.FirstName,
-		SecondName: dbUser.SecondName,
-		LastName:   dbUser.LastName,
-		Password:   dbUser.Password,
-		Age:        dbUser.Age,
-		Email:      dbUser.Email,
-		Phone:      dbUser.Phone,
-	}, nil
+	return toUser(dbUser), nil
 }
 
 func (u *userRepository) Delete(ctx context.Context, id *entity.UserID) error {
 	err := u.source.DeleteUser(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return err
-		}
 		return fmt.Errorf("can't delete user from db: %w", err)
 	}
 
 	return nil
 }
+
+func (u *userRepository) SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.User, error) {
+	dbUser, err := u.source.SetUserRole(ctx, id, role)
+	if err != nil {
+		return nil, fmt.Errorf("can't set user role in db: %w", err)
+	}
+
+	return toUser(dbUser), nil
+}
+
+func (u *userRepository) SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.User, error) {
+	dbUser, err := u.source.SetUserDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, fmt.Errorf("can't set user disabled in db: %w", err)
+	}
+
+	return toUser(dbUser), nil
+}
+
+func (u *userRepository) SetPassword(ctx context.Context, id *entity.UserID, password string) (*entity.User, error) {
+	dbUser, err := u.source.SetUserPassword(ctx, id, password)
+	if err != nil {
+		return nil, fmt.Errorf("can't set user password in db: %w", err)
+	}
+
+	return toUser(dbUser), nil
+}
+
+func (u *userRepository) Export(ctx context.Context, filter *entity.UserFilter, fn func(user *entity.User) error) error {
+	err := u.source.ExportUsers(ctx, filter, func(user *entity.UserDB) error {
+		return fn(toUser(user))
+	})
+	if err != nil {
+		return fmt.Errorf("can't export users from db: %w", err)
+	}
+
+	return nil
+}
+
+func (u *userRepository) Import(ctx context.Context, opts *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error) {
+	report, err := u.source.ImportUsers(ctx, opts, next)
+	if err != nil {
+		return nil, fmt.Errorf("can't import users to db: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetHistory возвращает страницу истории пользователя размером limit, начиная с записи перед cursor
+func (u *userRepository) GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error) {
+	entries, err := u.source.GetUserHistory(ctx, id, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user history from db: %w", err)
+	}
+
+	return toUserHistoryPage(entries, limit)
+}
+
+// GetAudit возвращает страницу действий пользователя размером limit, начиная с записи перед cursor
+func (u *userRepository) GetAudit(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error) {
+	entries, err := u.source.GetUserAudit(ctx, id, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user audit from db: %w", err)
+	}
+
+	return toUserHistoryPage(entries, limit)
+}
+
+func (u *userRepository) Erase(ctx context.Context, id *entity.UserID) error {
+	err := u.source.EraseUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't erase user in db: %w", err)
+	}
+
+	return nil
+}
+
+// toUserHistoryPage собирает страницу из limit+1 записей, лишняя запись означает наличие следующей страницы
+func toUserHistoryPage(entries []*entity.UserHistoryDB, limit int) (*entity.UserHistoryPage, error) {
+	page := &entity.UserHistoryPage{}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		page.NextCursor = entries[limit-1].ID
+	}
+
+	page.Entries = make([]*entity.UserHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		historyEntry, err := toUserHistoryEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		page.Entries = append(page.Entries, historyEntry)
+	}
+
+	return page, nil
+}
+
+func toUserHistoryEntry(entry *entity.UserHistoryDB) (*entity.UserHistoryEntry, error) {
+	res := &entity.UserHistoryEntry{
+		ID:        entry.ID,
+		UserID:    &entity.UserID{Id: entry.UserID},
+		Action:    entity.UserHistoryAction(entry.Action),
+		CreatedAt: entry.CreatedAt,
+	}
+	if entry.ActorID.Valid {
+		res.ActorID = &entity.UserID{Id: entry.ActorID.UUID}
+	}
+
+	var err error
+	if res.Before, err = toUserSnapshot(entry.Before); err != nil {
+		return nil, err
+	}
+	if res.After, err = toUserSnapshot(entry.After); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func toUserSnapshot(data []byte) (*entity.UserSnapshot, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var snapshot entity.UserSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("can't unmarshal user snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+func toUser(user *entity.UserDB) *entity.User {
+	return &entity.User{
+		ID: &entity.UserID{
+			Id: user.ID,
+		},
+		FirstName:  user.FirstName,
+		SecondName: user.SecondName,
+		LastName:   user.LastName,
+		Password:   user.Password,
+		Age:        user.Age,
+		Email:      user.Email,
+		Phone:      user.Phone,
+		Role:       user.Role,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+		ErasedAt:   user.ErasedAt,
+		DisabledAt: user.DisabledAt,
+	}
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-test-grpc-http/internal/db"
+	"go-test-grpc-http/internal/domain"
 	"go-test-grpc-http/internal/entity"
 	"reflect"
 	"testing"
@@ -21,11 +23,12 @@ func Test_userRepository_GetById(t *testing.T) {
 		id  *entity.UserID
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    *entity.User
-		setup   func(a args, f fields)
-		wantErr bool
+		name      string
+		args      args
+		want      *entity.User
+		setup     func(a args, f fields)
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "success: GetById userRepository",
@@ -59,6 +62,21 @@ func Test_userRepository_GetById(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error: GetById userRepository: not found",
+			args: args{
+				ctx: context.Background(),
+				id: &entity.UserID{
+					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				},
+			},
+			want: nil,
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserById(a.ctx, a.id).Return(nil, entity.ErrUserNotFound)
+			},
+			wantErr:   true,
+			wantErrIs: domain.ErrNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -76,6 +94,9 @@ func Test_userRepository_GetById(t *testing.T) {
 				t.Errorf("userRepository.GetUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("userRepository.GetUser() error = %v, want %v", err, tt.wantErrIs)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("userRepository.GetUser() = %v, want %v", got, tt.want)
 			}
@@ -327,3 +348,109 @@ func Test_userRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+func Test_userRepository_GetHistory(t *testing.T) {
+	type fields struct {
+		source *db.MockUserSource
+	}
+	type args struct {
+		ctx    context.Context
+		id     *entity.UserID
+		cursor int64
+		limit  int
+	}
+	id := uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")
+	actor := uuid.MustParse("0c2d4c1e-5d0f-4f63-9d56-0c1c1b0b6a11")
+	tests := []struct {
+		name    string
+		args    args
+		want    *entity.UserHistoryPage
+		setup   func(a args, f fields)
+		wantErr bool
+	}{
+		{
+			name: "success: GetHistory userRepository: has next page",
+			args: args{
+				ctx:   context.Background(),
+				id:    &entity.UserID{Id: id},
+				limit: 1,
+			},
+			want: &entity.UserHistoryPage{
+				Entries: []*entity.UserHistoryEntry{
+					{
+						ID:      3,
+						UserID:  &entity.UserID{Id: id},
+						Action:  entity.UserHistoryUpdate,
+						ActorID: &entity.UserID{Id: actor},
+						Before:  &entity.UserSnapshot{Age: 30},
+						After:   &entity.UserSnapshot{Age: 31},
+					},
+				},
+				NextCursor: 3,
+			},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserHistory(a.ctx, a.id, a.cursor, 2).Return([]*entity.UserHistoryDB{
+					{ID: 3, UserID: id, Action: "update", ActorID: uuid.NullUUID{UUID: actor, Valid: true}, Before: []byte(`{"age":30}`), After: []byte(`{"age":31}`)},
+					{ID: 1, UserID: id, Action: "create", After: []byte(`{"age":30}`)},
+				}, nil)
+			},
+		},
+		{
+			name: "success: GetHistory userRepository: last page",
+			args: args{
+				ctx:    context.Background(),
+				id:     &entity.UserID{Id: id},
+				cursor: 3,
+				limit:  1,
+			},
+			want: &entity.UserHistoryPage{
+				Entries: []*entity.UserHistoryEntry{
+					{
+						ID:     1,
+						UserID: &entity.UserID{Id: id},
+						Action: entity.UserHistoryCreate,
+						After:  &entity.UserSnapshot{Age: 30},
+					},
+				},
+			},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserHistory(a.ctx, a.id, a.cursor, 2).Return([]*entity.UserHistoryDB{
+					{ID: 1, UserID: id, Action: "create", After: []byte(`{"age":30}`)},
+				}, nil)
+			},
+		},
+		{
+			name: "error: GetHistory userRepository",
+			args: args{
+				ctx:   context.Background(),
+				id:    &entity.UserID{Id: id},
+				limit: 1,
+			},
+			setup: func(a args, f fields) {
+				f.source.EXPECT().GetUserHistory(a.ctx, a.id, a.cursor, 2).Return(nil, fmt.Errorf("can't get history from source"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				source: db.NewMockUserSource(ctrl),
+			}
+
+			r := NewUserRepository(f.source)
+
+			tt.setup(tt.args, f)
+
+			got, err := r.GetHistory(tt.args.ctx, tt.args.id, tt.args.cursor, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userRepository.GetHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userRepository.GetHistory() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
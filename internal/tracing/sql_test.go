@@ -0,0 +1,34 @@
+package tracing
+
+import "testing"
+
+func TestSanitizeSQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "placeholders are kept",
+			query: "SELECT * FROM users WHERE id = $1 LIMIT $2",
+			want:  "SELECT * FROM users WHERE id = $1 LIMIT $2",
+		},
+		{
+			name:  "string literals",
+			query: "SELECT id FROM users WHERE email = 'user@example.com' AND last_name = 'O''Brien'",
+			want:  "SELECT id FROM users WHERE email = ? AND last_name = ?",
+		},
+		{
+			name:  "numeric literals",
+			query: "SELECT * FROM users2 WHERE age > 18 AND score < 4.5",
+			want:  "SELECT * FROM users2 WHERE age > ? AND score < ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeSQL(tt.query); got != tt.want {
+				t.Errorf("SanitizeSQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"io"
+	"strconv"
+)
+
+// ErrInvalidExportOptions возвращается при некорректных параметрах экспорта
+var ErrInvalidExportOptions = domain.Invalid("invalid export options")
+
+func (u *userInteractor) Export(ctx context.Context, w io.Writer, opts *entity.UserExportOptions) (int, error) {
+	opts, err := normalizeExportOptions(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	encoder, err := newUserExportEncoder(opts.Format, w, opts.Columns)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err = u.repo.Export(ctx, &opts.Filter, func(user *entity.User) error {
+		rows++
+		return encoder.Encode(user)
+	})
+	if err != nil {
+		return rows, fmt.Errorf("can't export users by repository: %w", err)
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return rows, fmt.Errorf("can't write export: %w", err)
+	}
+
+	return rows, nil
+}
+
+// normalizeExportOptions проверяет параметры экспорта и подставляет значения по умолчанию
+func normalizeExportOptions(opts *entity.UserExportOptions) (*entity.UserExportOptions, error) {
+	res := *opts
+	if res.Format != entity.DataFormatCSV && res.Format != entity.DataFormatNDJSON {
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidExportOptions, res.Format)
+	}
+
+	if len(res.Columns) == 0 {
+		res.Columns = entity.UserExportColumns
+	}
+	seen := make(map[string]bool, len(res.Columns))
+	for _, column := range res.Columns {
+		if _, ok := userExportColumns[column]; !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidExportOptions, column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: duplicated column %q", ErrInvalidExportOptions, column)
+		}
+		seen[column] = true
+	}
+
+	if res.Filter.MinAge != nil && res.Filter.MaxAge != nil && *res.Filter.MinAge > *res.Filter.MaxAge {
+		return nil, fmt.Errorf("%w: min age is greater than max age", ErrInvalidExportOptions)
+	}
+
+	return &res, nil
+}
+
+// Значения колонок экспорта
+var userExportColumns = map[string]func(user *entity.User) interface{}{
+	"id":          func(user *entity.User) interface{} { return user.ID.String() },
+	"first_name":  func(user *entity.User) interface{} { return user.FirstName },
+	"second_name": func(user *entity.User) interface{} { return user.SecondName },
+	"last_name":   func(user *entity.User) interface{} { return user.LastName },
+	"age":         func(user *entity.User) interface{} { return user.Age },
+	"email":       func(user *entity.User) interface{} { return user.Email },
+	"phone":       func(user *entity.User) interface{} { return user.Phone },
+	"role":        func(user *entity.User) interface{} { return string(user.Role) },
+}
+
+// userExportEncoder записывает пользователей в выходной поток
+type userExportEncoder interface {
+	Encode(user *entity.User) error
+	Flush() error
+}
+
+func newUserExportEncoder(format entity.DataFormat, w io.Writer, columns []string) (userExportEncoder, error) {
+	switch format {
+	case entity.DataFormatCSV:
+		return newCSVExportEncoder(w, columns)
+	case entity.DataFormatNDJSON:
+		return newNDJSONExportEncoder(w, columns), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidExportOptions, format)
+	}
+}
+
+type csvExportEncoder struct {
+	writer  *csv.Writer
+	columns []string
+	record  []string
+}
+
+func newCSVExportEncoder(w io.Writer, columns []string) (*csvExportEncoder, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return nil, fmt.Errorf("can't write csv header: %w", err)
+	}
+
+	return &csvExportEncoder{
+		writer:  writer,
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}, nil
+}
+
+func (e *csvExportEncoder) Encode(user *entity.User) error {
+	for i, column := range e.columns {
+		switch value := userExportColumns[column](user).(type) {
+		case int:
+			e.record[i] = strconv.Itoa(value)
+		case string:
+			e.record[i] = value
+		}
+	}
+
+	return e.writer.Write(e.record)
+}
+
+func (e *csvExportEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonExportEncoder struct {
+	writer  io.Writer
+	columns []string
+	buf     bytes.Buffer
+}
+
+func newNDJSONExportEncoder(w io.Writer, columns []string) *ndjsonExportEncoder {
+	return &ndjsonExportEncoder{
+		writer:  w,
+		columns: columns,
+	}
+}
+
+// Encode записывает объект с колонками в заданном порядке
+func (e *ndjsonExportEncoder) Encode(user *entity.User) error {
+	e.buf.Reset()
+	e.buf.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(userExportColumns[column](user))
+		if err != nil {
+			return fmt.Errorf("can't marshal %s: %w", column, err)
+		}
+		e.buf.Write(key)
+		e.buf.WriteByte(':')
+		e.buf.Write(value)
+	}
+	e.buf.WriteString("}\n")
+
+	_, err := e.writer.Write(e.buf.Bytes())
+	return err
+}
+
+func (e *ndjsonExportEncoder) Flush() error {
+	return nil
+}
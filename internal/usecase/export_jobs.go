@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrExportJobNotFound возвращается, если задача выгрузки не найдена
+var ErrExportJobNotFound = domain.NotFound("export job not found")
+
+// exportJobs выполняет экспорт пользователей в фоне и сохраняет результат в файл в каталоге dir
+type exportJobs struct {
+	interactor UserInteractor
+	dir        string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.RWMutex
+	jobs map[string]*entity.ExportJob
+}
+
+func NewExportJobs(interactor UserInteractor, dir string) *exportJobs {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &exportJobs{
+		interactor: interactor,
+		dir:        dir,
+		ctx:        ctx,
+		cancel:     cancel,
+		jobs:       make(map[string]*entity.ExportJob),
+	}
+}
+
+func (e *exportJobs) Start(_ context.Context, opts *entity.UserExportOptions) (*entity.ExportJob, error) {
+	opts, err := normalizeExportOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(e.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("can't create export dir: %w", err)
+	}
+
+	id := uuid.New().String()
+	job := &entity.ExportJob{
+		ID:        id,
+		Status:    entity.ExportJobStatusRunning,
+		Options:   opts,
+		Path:      filepath.Join(e.dir, fmt.Sprintf("users-%s.%s", id, opts.Format)),
+		CreatedAt: time.Now(),
+	}
+
+	e.mu.Lock()
+	e.jobs[id] = job
+	res := e.copyJob(job)
+	e.mu.Unlock()
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		rows, err := e.run(e.ctx, job.Path, opts)
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		job.Rows = rows
+		job.FinishedAt = time.Now()
+		if err != nil {
+			job.Status = entity.ExportJobStatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = entity.ExportJobStatusDone
+	}()
+
+	return res, nil
+}
+
+// Get возвращает копию задачи или ErrExportJobNotFound, если задачи с таким id нет
+func (e *exportJobs) Get(_ context.Context, id string) (*entity.ExportJob, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	job, ok := e.jobs[id]
+	if !ok {
+		return nil, ErrExportJobNotFound
+	}
+
+	return e.copyJob(job), nil
+}
+
+// Stop отменяет выполняющиеся задачи и ожидает их завершения
+func (e *exportJobs) Stop(ctx context.Context) error {
+	e.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("can't wait for export jobs: %w", ctx.Err())
+	}
+}
+
+// run записывает экспорт во временный файл и переименовывает его после успешного завершения
+func (e *exportJobs) run(ctx context.Context, path string, opts *entity.UserExportOptions) (int, error) {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, fmt.Errorf("can't create export file: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	w := bufio.NewWriter(file)
+	rows, err := e.interactor.Export(ctx, w, opts)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return rows, fmt.Errorf("can't export users: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return rows, fmt.Errorf("can't save export file: %w", err)
+	}
+
+	return rows, nil
+}
+
+func (e *exportJobs) copyJob(job *entity.ExportJob) *entity.ExportJob {
+	res := *job
+	return &res
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/repository"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func Test_userInteractor_Export(t *testing.T) {
+	type fields struct {
+		userRepository *repository.MockUserRepository
+	}
+	type args struct {
+		ctx  context.Context
+		opts *entity.UserExportOptions
+	}
+	users := []*entity.User{
+		{
+			ID: &entity.UserID{
+				Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+			},
+			FirstName: "John",
+			LastName:  "Doe, Jr.",
+			Age:       30,
+			Email:     "doe@example.com",
+			Password:  "qwerty1234",
+			Role:      entity.RoleUser,
+		},
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		setup   func(a args, f fields)
+		wantErr error
+	}{
+		{
+			name: "success Export usecase: csv with all columns",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserExportOptions{Format: entity.DataFormatCSV},
+			},
+			want: "id,first_name,second_name,last_name,age,email,phone,role\n" +
+				"4a6e104d-9d7f-45ff-8de6-37993d709522,John,,\"Doe, Jr.\",30,doe@example.com,,user\n",
+			setup: func(a args, f fields) {
+				f.userRepository.EXPECT().Export(a.ctx, &a.opts.Filter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *entity.UserFilter, fn func(user *entity.User) error) error {
+						for _, user := range users {
+							if err := fn(user); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name: "success Export usecase: ndjson with selected columns",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserExportOptions{Format: entity.DataFormatNDJSON, Columns: []string{"email", "age"}},
+			},
+			want: `{"email":"doe@example.com","age":30}` + "\n",
+			setup: func(a args, f fields) {
+				f.userRepository.EXPECT().Export(a.ctx, &a.opts.Filter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *entity.UserFilter, fn func(user *entity.User) error) error {
+						return fn(users[0])
+					})
+			},
+		},
+		{
+			name: "error Export usecase: password column",
+			args: args{
+				ctx:  context.Background(),
+				opts: &entity.UserExportOptions{Format: entity.DataFormatCSV, Columns: []string{"email", "password"}},
+			},
+			setup:   func(a args, f fields) {},
+			wantErr: ErrInvalidExportOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				userRepository: repository.NewMockUserRepository(ctrl),
+			}
+
+			u := NewUserInteractor(f.userRepository)
+
+			tt.setup(tt.args, f)
+
+			var buf bytes.Buffer
+			_, err := u.Export(tt.args.ctx, &buf, tt.args.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userInteractor.Export() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if buf.String() != tt.want {
+				t.Errorf("userInteractor.Export() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
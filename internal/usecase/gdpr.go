@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"time"
+)
+
+// ExportData собирает архив всех данных о пользователе
+func (u *userInteractor) ExportData(ctx context.Context, id *entity.UserID) (*entity.UserDataArchive, error) {
+	user, err := u.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user by id from repository: %w", err)
+	}
+
+	history, err := collectHistory(ctx, id, u.repo.GetHistory)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user history by repository: %w", err)
+	}
+
+	audit, err := collectHistory(ctx, id, u.repo.GetAudit)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user audit by repository: %w", err)
+	}
+
+	user.Password = ""
+	return &entity.UserDataArchive{
+		ExportedAt: time.Now().UTC(),
+		Profile:    user,
+		History:    history,
+		Audit:      audit,
+		// Сеансы не хранятся: JWT проверяются по подписи без записи на сервере
+		Sessions: []*entity.UserSession{},
+	}, nil
+}
+
+// Erase удаляет персональные данные пользователя, сохраняя саму запись и историю
+func (u *userInteractor) Erase(ctx context.Context, id *entity.UserID) error {
+	err := u.repo.Erase(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't erase user by repository: %w", err)
+	}
+
+	return nil
+}
+
+type historyPageFunc func(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error)
+
+// collectHistory читает все страницы истории
+func collectHistory(ctx context.Context, id *entity.UserID, getPage historyPageFunc) ([]*entity.UserHistoryEntry, error) {
+	entries := []*entity.UserHistoryEntry{}
+	var cursor int64
+	for {
+		page, err := getPage(ctx, id, cursor, MaxHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, page.Entries...)
+		if page.NextCursor == 0 {
+			return entries, nil
+		}
+		cursor = page.NextCursor
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/repository"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func Test_userInteractor_ExportData(t *testing.T) {
+	type fields struct {
+		userRepository *repository.MockUserRepository
+	}
+	type args struct {
+		ctx context.Context
+		id  *entity.UserID
+	}
+	id := &entity.UserID{Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522")}
+	tests := []struct {
+		name    string
+		args    args
+		want    *entity.UserDataArchive
+		setup   func(a args, f fields)
+		wantErr bool
+	}{
+		{
+			name: "success ExportData usecase: all pages collected",
+			args: args{
+				ctx: context.Background(),
+				id:  id,
+			},
+			want: &entity.UserDataArchive{
+				Profile:  &entity.User{ID: id, Email: "doe@example.com"},
+				History:  []*entity.UserHistoryEntry{{ID: 3}, {ID: 1}},
+				Audit:    []*entity.UserHistoryEntry{},
+				Sessions: []*entity.UserSession{},
+			},
+			setup: func(a args, f fields) {
+				f.userRepository.EXPECT().GetById(a.ctx, a.id).
+					Return(&entity.User{ID: id, Email: "doe@example.com", Password: "qwerty1234"}, nil)
+				gomock.InOrder(
+					f.userRepository.EXPECT().GetHistory(a.ctx, a.id, int64(0), MaxHistoryPageSize).
+						Return(&entity.UserHistoryPage{Entries: []*entity.UserHistoryEntry{{ID: 3}}, NextCursor: 3}, nil),
+					f.userRepository.EXPECT().GetHistory(a.ctx, a.id, int64(3), MaxHistoryPageSize).
+						Return(&entity.UserHistoryPage{Entries: []*entity.UserHistoryEntry{{ID: 1}}}, nil),
+				)
+				f.userRepository.EXPECT().GetAudit(a.ctx, a.id, int64(0), MaxHistoryPageSize).
+					Return(&entity.UserHistoryPage{}, nil)
+			},
+		},
+		{
+			name: "error ExportData usecase: user not found",
+			args: args{
+				ctx: context.Background(),
+				id:  id,
+			},
+			want: nil,
+			setup: func(a args, f fields) {
+				f.userRepository.EXPECT().GetById(a.ctx, a.id).Return(nil, entity.ErrUserNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error ExportData usecase: can't get history",
+			args: args{
+				ctx: context.Background(),
+				id:  id,
+			},
+			want: nil,
+			setup: func(a args, f fields) {
+				f.userRepository.EXPECT().GetById(a.ctx, a.id).Return(&entity.User{ID: id}, nil)
+				f.userRepository.EXPECT().GetHistory(a.ctx, a.id, int64(0), MaxHistoryPageSize).
+					Return(nil, fmt.Errorf("can't get history"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				userRepository: repository.NewMockUserRepository(ctrl),
+			}
+
+			tt.setup(tt.args, f)
+
+			u := NewUserInteractor(f.userRepository)
+
+			got, err := u.ExportData(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userInteractor.ExportData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				got.ExportedAt = tt.want.ExportedAt
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userInteractor.ExportData() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"go-test-grpc-http/internal/entity"
+)
+
+const (
+	DefaultHistoryPageSize = 20  // Размер страницы истории по умолчанию
+	MaxHistoryPageSize     = 100 // Максимальный размер страницы истории
+)
+
+// GetHistory возвращает страницу истории пользователя от новых записей к старым.
+// cursor - курсор из предыдущей страницы, 0 для первой.
+func (u *userInteractor) GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error) {
+	if limit <= 0 {
+		limit = DefaultHistoryPageSize
+	}
+	if limit > MaxHistoryPageSize {
+		limit = MaxHistoryPageSize
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+
+	page, err := u.repo.GetHistory(ctx, id, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user history by repository: %w", err)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultImportBatchSize = 500
+	MaxImportBatchSize     = 5000
+
+	// Максимальная длина строки NDJSON
+	maxImportLineSize = 1 << 20
+)
+
+// ErrMalformedImport возвращается, если входные данные импорта не могут быть разобраны целиком
+var ErrMalformedImport = domain.Invalid("malformed import data")
+
+func (u *userInteractor) Import(ctx context.Context, r io.Reader, opts *entity.UserImportOptions) (*entity.UserImportReport, error) {
+	opts = normalizeImportOptions(opts)
+
+	decoder, err := newUserImportDecoder(opts.Format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Адреса, уже встреченные в импорте, и номера их строк
+	emails := make(map[string]int)
+	next := func() ([]*entity.UserImportRow, error) {
+		batch := make([]*entity.UserImportRow, 0, opts.BatchSize)
+		for len(batch) < opts.BatchSize {
+			row, err := decoder.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if row.Err == nil {
+				row.User.Normalize()
+				row.Err = ValidateUserCreate(row.User)
+			}
+			if row.Err == nil {
+				if line, ok := emails[row.User.Email]; ok {
+					row.Err = fmt.Errorf("email %s is duplicated at line %d", row.User.Email, line)
+				} else {
+					emails[row.User.Email] = row.Line
+				}
+			}
+			batch = append(batch, row)
+		}
+		if len(batch) == 0 {
+			return nil, io.EOF
+		}
+
+		return batch, nil
+	}
+
+	report, err := u.repo.Import(ctx, opts, next)
+	if err != nil {
+		return nil, fmt.Errorf("can't import users by repository: %w", err)
+	}
+
+	return report, nil
+}
+
+func normalizeImportOptions(opts *entity.UserImportOptions) *entity.UserImportOptions {
+	res := *opts
+	if res.Mode == "" {
+		res.Mode = entity.ImportModeAllOrNothing
+	}
+	if res.BatchSize <= 0 {
+		res.BatchSize = DefaultImportBatchSize
+	}
+	if res.BatchSize > MaxImportBatchSize {
+		res.BatchSize = MaxImportBatchSize
+	}
+
+	return &res
+}
+
+// userImportDecoder построчно разбирает входные данные импорта
+type userImportDecoder interface {
+	// Next возвращает очередную строку или io.EOF.
+	// Ошибки отдельной строки возвращаются в UserImportRow.Err, ошибка Next прерывает импорт.
+	Next() (*entity.UserImportRow, error)
+}
+
+func newUserImportDecoder(format entity.DataFormat, r io.Reader) (userImportDecoder, error) {
+	switch format {
+	case entity.DataFormatCSV:
+		return newCSVImportDecoder(r)
+	case entity.DataFormatNDJSON:
+		return newNDJSONImportDecoder(r), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrMalformedImport, format)
+	}
+}
+
+// Колонки CSV и соответствующие им поля пользователя
+var csvImportColumns = map[string]func(user *entity.UserCreate, value string) error{
+	"first_name":  func(user *entity.UserCreate, value string) error { user.FirstName = value; return nil },
+	"second_name": func(user *entity.UserCreate, value string) error { user.SecondName = value; return nil },
+	"last_name":   func(user *entity.UserCreate, value string) error { user.LastName = value; return nil },
+	"email":       func(user *entity.UserCreate, value string) error { user.Email = value; return nil },
+	"phone":       func(user *entity.UserCreate, value string) error { user.Phone = value; return nil },
+	"password":    func(user *entity.UserCreate, value string) error { user.Password = value; return nil },
+	"age": func(user *entity.UserCreate, value string) error {
+		if value == "" {
+			return nil
+		}
+		age, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("age %q is not a number", value)
+		}
+		user.Age = age
+		return nil
+	},
+}
+
+type csvImportDecoder struct {
+	reader  *csv.Reader
+	columns []string
+}
+
+func newCSVImportDecoder(r io.Reader) (*csvImportDecoder, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: csv header is missing", ErrMalformedImport)
+		}
+		return nil, fmt.Errorf("%w: can't read csv header: %v", ErrMalformedImport, err)
+	}
+
+	columns := make([]string, 0, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		if _, ok := csvImportColumns[column]; !ok {
+			return nil, fmt.Errorf("%w: unknown csv column %q", ErrMalformedImport, column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: duplicated csv column %q", ErrMalformedImport, column)
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+
+	return &csvImportDecoder{
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+func (d *csvImportDecoder) Next() (*entity.UserImportRow, error) {
+	record, err := d.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &entity.UserImportRow{
+				Line: parseErr.StartLine,
+				Err:  parseErr.Err,
+			}, nil
+		}
+		return nil, fmt.Errorf("can't read csv: %w", err)
+	}
+
+	line, _ := d.reader.FieldPos(0)
+	row := &entity.UserImportRow{
+		Line: line,
+		User: &entity.UserCreate{},
+	}
+	if len(record) != len(d.columns) {
+		row.Err = fmt.Errorf("expected %d fields, got %d", len(d.columns), len(record))
+		return row, nil
+	}
+
+	for i, value := range record {
+		if err := csvImportColumns[d.columns[i]](row.User, strings.TrimSpace(value)); err != nil {
+			row.Err = err
+			return row, nil
+		}
+	}
+
+	return row, nil
+}
+
+// userImportRecord представление строки NDJSON
+type userImportRecord struct {
+	FirstName  string `json:"first_name"`
+	SecondName string `json:"second_name"`
+	LastName   string `json:"last_name"`
+	Age        int    `json:"age"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	Password   string `json:"password"`
+}
+
+type ndjsonImportDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONImportDecoder(r io.Reader) *ndjsonImportDecoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+
+	return &ndjsonImportDecoder{
+		scanner: scanner,
+	}
+}
+
+func (d *ndjsonImportDecoder) Next() (*entity.UserImportRow, error) {
+	for d.scanner.Scan() {
+		d.line++
+		data := bytes.TrimSpace(d.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := &entity.UserImportRow{
+			Line: d.line,
+		}
+
+		var record userImportRecord
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			row.Err = fmt.Errorf("invalid json: %v", err)
+			return row, nil
+		}
+		if decoder.More() {
+			row.Err = fmt.Errorf("invalid json: unexpected data after object")
+			return row, nil
+		}
+
+		row.User = &entity.UserCreate{
+			FirstName:  strings.TrimSpace(record.FirstName),
+			SecondName: strings.TrimSpace(record.SecondName),
+			LastName:   strings.TrimSpace(record.LastName),
+			Age:        record.Age,
+			Email:      strings.TrimSpace(record.Email),
+			Phone:      strings.TrimSpace(record.Phone),
+			Password:   record.Password,
+		}
+
+		return row, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("%w: line %d is too long", ErrMalformedImport, d.line+1)
+		}
+		return nil, fmt.Errorf("can't read ndjson: %w", err)
+	}
+
+	return nil, io.EOF
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/repository"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func Test_userInteractor_Import(t *testing.T) {
+	type fields struct {
+		userRepository *repository.MockUserRepository
+	}
+	type args struct {
+		ctx  context.Context
+		data string
+		opts *entity.UserImportOptions
+	}
+	// row описывает строку, переданную в репозиторий
+	type row struct {
+		Line  int
+		Email string
+		Err   string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantBatches [][]row
+		wantErr     error
+	}{
+		{
+			name: "success Import usecase: csv",
+			args: args{
+				ctx: context.Background(),
+				data: "first_name,last_name,email,age,password\n" +
+					"John,Doe,john@example.com,30,qwerty\n" +
+					"Jane,Doe,jane@example.com,abc,qwerty\n" +
+					"Jim,Doe,john@example.com,40,qwerty\n" +
+					"Jack,Doe\n",
+				opts: &entity.UserImportOptions{Format: entity.DataFormatCSV, BatchSize: 2},
+			},
+			wantBatches: [][]row{
+				{
+					{Line: 2, Email: "john@example.com"},
+					{Line: 3, Email: "jane@example.com", Err: `age "abc" is not a number`},
+				},
+				{
+					{Line: 4, Email: "john@example.com", Err: "email john@example.com is duplicated at line 2"},
+					{Line: 5, Err: "expected 5 fields, got 2"},
+				},
+			},
+		},
+		{
+			name: "success Import usecase: ndjson",
+			args: args{
+				ctx: context.Background(),
+				data: `{"first_name":"John","last_name":"Doe","email":"john@example.com","age":30,"password":"qwerty"}` + "\n" +
+					"\n" +
+					`{"first_name":"Jane","last_name":"Doe","email":"jane","password":"qwerty"}` + "\n" +
+					`{"first_name":"Jim","role":"admin"}` + "\n",
+				opts: &entity.UserImportOptions{Format: entity.DataFormatNDJSON},
+			},
+			wantBatches: [][]row{
+				{
+					{Line: 1, Email: "john@example.com"},
+					{Line: 3, Email: "jane", Err: "email is invalid"},
+					{Line: 4, Err: `invalid json: json: unknown field "role"`},
+				},
+			},
+		},
+		{
+			name: "error Import usecase: unknown csv column",
+			args: args{
+				ctx:  context.Background(),
+				data: "first_name,role\nJohn,admin\n",
+				opts: &entity.UserImportOptions{Format: entity.DataFormatCSV},
+			},
+			wantErr: ErrMalformedImport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				userRepository: repository.NewMockUserRepository(ctrl),
+			}
+
+			var gotBatches [][]row
+			f.userRepository.EXPECT().Import(tt.args.ctx, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *entity.UserImportOptions, next entity.UserImportBatchFunc) (*entity.UserImportReport, error) {
+					for {
+						batch, err := next()
+						if errors.Is(err, io.EOF) {
+							return &entity.UserImportReport{}, nil
+						}
+						if err != nil {
+							return nil, err
+						}
+						var rows []row
+						for _, r := range batch {
+							got := row{Line: r.Line}
+							if r.User != nil {
+								got.Email = r.User.Email
+							}
+							got.Err = errString(r.Err)
+							rows = append(rows, got)
+						}
+						gotBatches = append(gotBatches, rows)
+					}
+				}).AnyTimes()
+
+			u := NewUserInteractor(f.userRepository)
+
+			_, err := u.Import(tt.args.ctx, strings.NewReader(tt.args.data), tt.args.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userInteractor.Import() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotBatches, tt.wantBatches) {
+				t.Errorf("userInteractor.Import() batches = %+v, want %+v", gotBatches, tt.wantBatches)
+			}
+		})
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"go-test-grpc-http/internal/entity"
+	"io"
 )
 
 //go:generate mockgen -source=./interfaces.go -destination=./usecases_mock.go -package=usecase
@@ -14,5 +15,19 @@ type UserInteractor interface {
 	GetIdByEmail(ctx context.Context, email string) (*entity.UserID, error)
 	Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.User, error)
 	Delete(ctx context.Context, id *entity.UserID) error
+	SignIn(ctx context.Context, email string) (*entity.UserID, error)
+	SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.User, error)
+	SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.User, error)
+	ResetPassword(ctx context.Context, id *entity.UserID, password string) (*entity.User, error)
+	GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error)
+	ExportData(ctx context.Context, id *entity.UserID) (*entity.UserDataArchive, error)
+	Erase(ctx context.Context, id *entity.UserID) error
+	Export(ctx context.Context, w io.Writer, opts *entity.UserExportOptions) (int, error)
+	Import(ctx context.Context, r io.Reader, opts *entity.UserImportOptions) (*entity.UserImportReport, error)
+}
+
+type ExportJobInteractor interface {
+	Start(ctx context.Context, opts *entity.UserExportOptions) (*entity.ExportJob, error)
+	Get(ctx context.Context, id string) (*entity.ExportJob, error)
+	Stop(ctx context.Context) error
 }
- 
\ No newline at end of file
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"go-test-grpc-http/internal/entity"
+	"go-test-grpc-http/internal/tracing"
+	"io"
+)
+
+// tracedInteractor создает спан UserInteractor.<метод> для каждого вызова сценария
+type tracedInteractor struct {
+	next UserInteractor
+}
+
+// Trace оборачивает сценарии работы с пользователями трассировкой каждого метода
+func Trace(next UserInteractor) UserInteractor {
+	return &tracedInteractor{
+		next: next,
+	}
+}
+
+func (t *tracedInteractor) Create(ctx context.Context, user *entity.UserCreate) (res *entity.UserID, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.Create")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Create(ctx, user)
+}
+
+func (t *tracedInteractor) GetById(ctx context.Context, id *entity.UserID) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.GetById")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetById(ctx, id)
+}
+
+func (t *tracedInteractor) GetByEmail(ctx context.Context, email string) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.GetByEmail")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetByEmail(ctx, email)
+}
+
+func (t *tracedInteractor) GetIdByEmail(ctx context.Context, email string) (res *entity.UserID, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.GetIdByEmail")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetIdByEmail(ctx, email)
+}
+
+func (t *tracedInteractor) Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.Update")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Update(ctx, id, user)
+}
+
+func (t *tracedInteractor) Delete(ctx context.Context, id *entity.UserID) (err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.Delete")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Delete(ctx, id)
+}
+
+func (t *tracedInteractor) SignIn(ctx context.Context, email string) (res *entity.UserID, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.SignIn")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SignIn(ctx, email)
+}
+
+func (t *tracedInteractor) SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.SetRole")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SetRole(ctx, id, role)
+}
+
+func (t *tracedInteractor) SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.SetDisabled")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SetDisabled(ctx, id, disabled)
+}
+
+func (t *tracedInteractor) ResetPassword(ctx context.Context, id *entity.UserID, password string) (res *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ResetPassword(ctx, id, password)
+}
+
+func (t *tracedInteractor) GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (res *entity.UserHistoryPage, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.GetHistory")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetHistory(ctx, id, cursor, limit)
+}
+
+func (t *tracedInteractor) ExportData(ctx context.Context, id *entity.UserID) (res *entity.UserDataArchive, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.ExportData")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ExportData(ctx, id)
+}
+
+func (t *tracedInteractor) Erase(ctx context.Context, id *entity.UserID) (err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.Erase")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Erase(ctx, id)
+}
+
+func (t *tracedInteractor) Export(ctx context.Context, w io.Writer, opts *entity.UserExportOptions) (res int, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.Export")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Export(ctx, w, opts)
+}
+
+func (t *tracedInteractor) Import(ctx context.Context, r io.Reader, opts *entity.UserImportOptions) (res *entity.UserImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "UserInteractor.Import")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Import(ctx, r, opts)
+}
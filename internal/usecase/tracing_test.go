@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"go-test-grpc-http/internal/entity"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+	}{
+		{name: "ok", err: nil, wantStatus: codes.Unset},
+		{name: "error", err: entity.ErrUserNotFound, wantStatus: codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			interactor := NewMockUserInteractor(ctrl)
+			interactor.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.err)
+
+			err := Trace(interactor).Delete(context.Background(), &entity.UserID{})
+			if err != tt.err {
+				t.Errorf("Delete() error = %v, want %v", err, tt.err)
+			}
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			if span.Name() != "UserInteractor.Delete" {
+				t.Errorf("span name = %q, want %q", span.Name(), "UserInteractor.Delete")
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package usecase
 import (
 	context "context"
 	entity "go-test-grpc-http/internal/entity"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -64,6 +65,50 @@ func (mr *MockUserInteractorMockRecorder) Delete(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserInteractor)(nil).Delete), ctx, id)
 }
 
+// Erase mocks base method.
+func (m *MockUserInteractor) Erase(ctx context.Context, id *entity.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erase", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Erase indicates an expected call of Erase.
+func (mr *MockUserInteractorMockRecorder) Erase(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erase", reflect.TypeOf((*MockUserInteractor)(nil).Erase), ctx, id)
+}
+
+// Export mocks base method.
+func (m *MockUserInteractor) Export(ctx context.Context, w io.Writer, opts *entity.UserExportOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w, opts)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserInteractorMockRecorder) Export(ctx, w, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserInteractor)(nil).Export), ctx, w, opts)
+}
+
+// ExportData mocks base method.
+func (m *MockUserInteractor) ExportData(ctx context.Context, id *entity.UserID) (*entity.UserDataArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportData", ctx, id)
+	ret0, _ := ret[0].(*entity.UserDataArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportData indicates an expected call of ExportData.
+func (mr *MockUserInteractorMockRecorder) ExportData(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportData", reflect.TypeOf((*MockUserInteractor)(nil).ExportData), ctx, id)
+}
+
 // GetByEmail mocks base method.
 func (m *MockUserInteractor) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +139,21 @@ func (mr *MockUserInteractorMockRecorder) GetById(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockUserInteractor)(nil).GetById), ctx, id)
 }
 
+// GetHistory mocks base method.
+func (m *MockUserInteractor) GetHistory(ctx context.Context, id *entity.UserID, cursor int64, limit int) (*entity.UserHistoryPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, id, cursor, limit)
+	ret0, _ := ret[0].(*entity.UserHistoryPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockUserInteractorMockRecorder) GetHistory(ctx, id, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockUserInteractor)(nil).GetHistory), ctx, id, cursor, limit)
+}
+
 // GetIdByEmail mocks base method.
 func (m *MockUserInteractor) GetIdByEmail(ctx context.Context, email string) (*entity.UserID, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +169,81 @@ func (mr *MockUserInteractorMockRecorder) GetIdByEmail(ctx, email interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdByEmail", reflect.TypeOf((*MockUserInteractor)(nil).GetIdByEmail), ctx, email)
 }
 
+// Import mocks base method.
+func (m *MockUserInteractor) Import(ctx context.Context, r io.Reader, opts *entity.UserImportOptions) (*entity.UserImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, r, opts)
+	ret0, _ := ret[0].(*entity.UserImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserInteractorMockRecorder) Import(ctx, r, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserInteractor)(nil).Import), ctx, r, opts)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserInteractor) ResetPassword(ctx context.Context, id *entity.UserID, password string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, id, password)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserInteractorMockRecorder) ResetPassword(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserInteractor)(nil).ResetPassword), ctx, id, password)
+}
+
+// SetDisabled mocks base method.
+func (m *MockUserInteractor) SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", ctx, id, disabled)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockUserInteractorMockRecorder) SetDisabled(ctx, id, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockUserInteractor)(nil).SetDisabled), ctx, id, disabled)
+}
+
+// SetRole mocks base method.
+func (m *MockUserInteractor) SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, id, role)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserInteractorMockRecorder) SetRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserInteractor)(nil).SetRole), ctx, id, role)
+}
+
+// SignIn mocks base method.
+func (m *MockUserInteractor) SignIn(ctx context.Context, email string) (*entity.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, email)
+	ret0, _ := ret[0].(*entity.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockUserInteractorMockRecorder) SignIn(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUserInteractor)(nil).SignIn), ctx, email)
+}
+
 // Update mocks base method.
 func (m *MockUserInteractor) Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -123,3 +258,70 @@ func (mr *MockUserInteractorMockRecorder) Update(ctx, id, user interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserInteractor)(nil).Update), ctx, id, user)
 }
+
+// MockExportJobInteractor is a mock of ExportJobInteractor interface.
+type MockExportJobInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportJobInteractorMockRecorder
+}
+
+// MockExportJobInteractorMockRecorder is the mock recorder for MockExportJobInteractor.
+type MockExportJobInteractorMockRecorder struct {
+	mock *MockExportJobInteractor
+}
+
+// NewMockExportJobInteractor creates a new mock instance.
+func NewMockExportJobInteractor(ctrl *gomock.Controller) *MockExportJobInteractor {
+	mock := &MockExportJobInteractor{ctrl: ctrl}
+	mock.recorder = &MockExportJobInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportJobInteractor) EXPECT() *MockExportJobInteractorMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockExportJobInteractor) Get(ctx context.Context, id string) (*entity.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExportJobInteractorMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExportJobInteractor)(nil).Get), ctx, id)
+}
+
+// Start mocks base method.
+func (m *MockExportJobInteractor) Start(ctx context.Context, opts *entity.UserExportOptions) (*entity.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, opts)
+	ret0, _ := ret[0].(*entity.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockExportJobInteractorMockRecorder) Start(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockExportJobInteractor)(nil).Start), ctx, opts)
+}
+
+// Stop mocks base method.
+func (m *MockExportJobInteractor) Stop(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockExportJobInteractorMockRecorder) Stop(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockExportJobInteractor)(nil).Stop), ctx)
+}
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"go-test-grpc-http/internal/entity"
 	"go-test-grpc-http/internal/repository"
@@ -19,6 +18,10 @@ func NewUserInteractor(repo repository.UserRepository) *userInteractor {
 }
 
 func (u *userInteractor) Create(ctx context.Context, user *entity.UserCreate) (*entity.UserID, error) {
+	user.Normalize()
+	if err := ValidateUserCreate(user); err != nil {
+		return nil, fmt.Errorf("can't create user: %w", err)
+	}
 	userId, err := u.repo.Create(ctx, user)
 	if err != nil {
 		return nil, fmt.Errorf("can't create user by repository: %w", err)
@@ -37,7 +40,7 @@ func (u *userInteractor) GetById(ctx context.Context, id *entity.UserID) (*entit
 }
 
 func (u *userInteractor) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
-	user, err := u.repo.GetByEmail(ctx, email)
+	user, err := u.repo.GetByEmail(ctx, entity.NormalizeEmail(email))
 	if err != nil {
 		return nil, fmt.Errorf("can't get user by email from repository %w", err)
 	}
@@ -46,7 +49,7 @@ func (u *userInteractor) GetByEmail(ctx context.Context, email string) (*entity.
 }
 
 func (u *userInteractor) GetIdByEmail(ctx context.Context, email string) (*entity.UserID, error) {
-	id, err := u.repo.GetIdByEmail(ctx, email)
+	id, err := u.repo.GetIdByEmail(ctx, entity.NormalizeEmail(email))
 	if err != nil {
 		return nil, fmt.Errorf("can't get user id by email from repository %w", err)
 	}
@@ -55,6 +58,10 @@ func (u *userInteractor) GetIdByEmail(ctx context.Context, email string) (*entit
 }
 
 func (u *userInteractor) Update(ctx context.Context, id *entity.UserID, user *entity.UserCreate) (*entity.User, error) {
+	user.Normalize()
+	if err := ValidateUserCreate(user); err != nil {
+		return nil, fmt.Errorf("can't update user: %w", err)
+	}
 	dbUser, err := u.repo.Update(ctx, id, user)
 	if err != nil {
 		return nil, fmt.Errorf("can't update user by repository: %w", err)
@@ -66,11 +73,55 @@ func (u *userInteractor) Update(ctx context.Context, id *entity.UserID, user *en
 func (u *userInteractor) Delete(ctx context.Context, id *entity.UserID) error {
 	err := u.repo.Delete(ctx, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return err
-		}
 		return fmt.Errorf("can't delete user by repository: %w", err)
 	}
 
 	return nil
 }
+
+// SignIn находит пользователя для входа по email. Заблокированному пользователю возвращает entity.ErrUserDisabled.
+func (u *userInteractor) SignIn(ctx context.Context, email string) (*entity.UserID, error) {
+	user, err := u.repo.GetByEmail(ctx, entity.NormalizeEmail(email))
+	if err != nil {
+		return nil, fmt.Errorf("can't get user by email from repository: %w", err)
+	}
+	if user.DisabledAt != nil {
+		return nil, entity.ErrUserDisabled
+	}
+
+	return user.ID, nil
+}
+
+func (u *userInteractor) SetRole(ctx context.Context, id *entity.UserID, role entity.Role) (*entity.User, error) {
+	if err := ValidateRole(role); err != nil {
+		return nil, fmt.Errorf("can't set user role: %w", err)
+	}
+	user, err := u.repo.SetRole(ctx, id, role)
+	if err != nil {
+		return nil, fmt.Errorf("can't set user role by repository: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetDisabled блокирует пользователя или снимает блокировку. Заблокированный пользователь не может войти.
+func (u *userInteractor) SetDisabled(ctx context.Context, id *entity.UserID, disabled bool) (*entity.User, error) {
+	user, err := u.repo.SetDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, fmt.Errorf("can't set user disabled by repository: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *userInteractor) ResetPassword(ctx context.Context, id *entity.UserID, password string) (*entity.User, error) {
+	if err := ValidatePassword(password); err != nil {
+		return nil, fmt.Errorf("can't reset user password: %w", err)
+	}
+	user, err := u.repo.SetPassword(ctx, id, password)
+	if err != nil {
+		return nil, fmt.Errorf("can't set user password by repository: %w", err)
+	}
+
+	return user, nil
+}
@@ -2,11 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"go-test-grpc-http/internal/domain"
 	"go-test-grpc-http/internal/entity"
 	"go-test-grpc-http/internal/repository"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -138,6 +142,36 @@ func Test_userInteractor_Create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			// Хранилище сравнивает email только без учета регистра, пробелы убирает сценарий
+			name: "success Create usecase: email is normalized",
+			args: args{
+				ctx: context.Background(),
+				user: &entity.UserCreate{
+					FirstName:  "John",
+					LastName:   "Doe",
+					SecondName: "DoeD",
+					Age:        30,
+					Email:      " Doe@Example.com ",
+					Phone:      "+1111111111",
+					Password:   "qwerty1234",
+				},
+			},
+			want: &entity.UserID{
+				Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+			},
+			setup: func(a args, f fields) {
+				f.repo.EXPECT().Create(a.ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *entity.UserCreate) (*entity.UserID, error) {
+					if user.Email != "doe@example.com" {
+						return nil, fmt.Errorf("email %q is not normalized", user.Email)
+					}
+					return &entity.UserID{
+						Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+					}, nil
+				})
+			},
+			wantErr: false,
+		},
 		{
 			name: "error Create usecase",
 			args: args{
@@ -158,6 +192,22 @@ func Test_userInteractor_Create(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error Create usecase: invalid role",
+			args: args{
+				ctx: context.Background(),
+				user: &entity.UserCreate{
+					FirstName: "John",
+					LastName:  "Doe",
+					Email:     "doe@example.com",
+					Password:  "qwerty1234",
+					Role:      "root",
+				},
+			},
+			want:    nil,
+			setup:   func(a args, f fields) {},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -207,13 +257,13 @@ func Test_userInteractor_Update(t *testing.T) {
 					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 				},
 				user: &entity.UserCreate{
-					FirstName:  "",
-					LastName:   "",
-					SecondName: "",
+					FirstName:  "John",
+					LastName:   "Doe",
+					SecondName: "DoeD",
 					Age:        31,
-					Email:      "",
-					Phone:      "",
-					Password:   "",
+					Email:      "doe@example.com",
+					Phone:      "+1111111111",
+					Password:   "qwerty1234",
 				},
 			},
 			want: &entity.User{
@@ -252,13 +302,13 @@ func Test_userInteractor_Update(t *testing.T) {
 					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
 				},
 				user: &entity.UserCreate{
-					FirstName:  "",
-					LastName:   "",
-					SecondName: "",
+					FirstName:  "John",
+					LastName:   "Doe",
+					SecondName: "DoeD",
 					Age:        31,
-					Email:      "",
-					Phone:      "",
-					Password:   "",
+					Email:      "doe@example.com",
+					Phone:      "+1111111111",
+					Password:   "qwerty1234",
 				},
 			},
 			want: nil,
@@ -267,6 +317,25 @@ func Test_userInteractor_Update(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error Update usecase: invalid user",
+			args: args{
+				ctx: context.Background(),
+				id: &entity.UserID{
+					Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+				},
+				user: &entity.UserCreate{
+					FirstName: "John",
+					LastName:  "Doe",
+					Age:       -1,
+					Email:     "doe",
+					Password:  "qwerty1234",
+				},
+			},
+			want:    nil,
+			setup:   func(a args, f fields) {},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -354,3 +423,173 @@ func Test_userInteractor_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_userInteractor_SignIn(t *testing.T) {
+	type fields struct {
+		repo *repository.MockUserRepository
+	}
+	id := &entity.UserID{
+		Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+	}
+	disabledAt := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		email   string
+		want    *entity.UserID
+		setup   func(f fields)
+		wantErr error
+	}{
+		{
+			name:  "success SignIn usecase",
+			email: " Doe@Example.com",
+			want:  id,
+			setup: func(f fields) {
+				f.repo.EXPECT().GetByEmail(gomock.Any(), "doe@example.com").Return(&entity.User{ID: id}, nil)
+			},
+		},
+		{
+			name:  "disabled user",
+			email: "doe@example.com",
+			setup: func(f fields) {
+				f.repo.EXPECT().GetByEmail(gomock.Any(), "doe@example.com").Return(&entity.User{ID: id, DisabledAt: &disabledAt}, nil)
+			},
+			wantErr: entity.ErrUserDisabled,
+		},
+		{
+			name:  "unknown user",
+			email: "doe@example.com",
+			setup: func(f fields) {
+				f.repo.EXPECT().GetByEmail(gomock.Any(), "doe@example.com").Return(nil, entity.ErrUserNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				repo: repository.NewMockUserRepository(ctrl),
+			}
+			u := &userInteractor{
+				repo: f.repo,
+			}
+
+			tt.setup(f)
+
+			got, err := u.SignIn(context.Background(), tt.email)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userInteractor.SignIn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userInteractor.SignIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userInteractor_SetRole(t *testing.T) {
+	type fields struct {
+		repo *repository.MockUserRepository
+	}
+	id := &entity.UserID{
+		Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+	}
+	tests := []struct {
+		name    string
+		role    entity.Role
+		want    *entity.User
+		setup   func(f fields)
+		wantErr error
+	}{
+		{
+			name: "success SetRole usecase",
+			role: entity.RoleAdmin,
+			want: &entity.User{ID: id, Role: entity.RoleAdmin},
+			setup: func(f fields) {
+				f.repo.EXPECT().SetRole(gomock.Any(), id, entity.RoleAdmin).Return(&entity.User{ID: id, Role: entity.RoleAdmin}, nil)
+			},
+		},
+		{
+			name:    "unknown role",
+			role:    entity.Role("root"),
+			setup:   func(f fields) {},
+			wantErr: domain.ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				repo: repository.NewMockUserRepository(ctrl),
+			}
+			u := &userInteractor{
+				repo: f.repo,
+			}
+
+			tt.setup(f)
+
+			got, err := u.SetRole(context.Background(), id, tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userInteractor.SetRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userInteractor.SetRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userInteractor_ResetPassword(t *testing.T) {
+	type fields struct {
+		repo *repository.MockUserRepository
+	}
+	id := &entity.UserID{
+		Id: uuid.MustParse("4a6e104d-9d7f-45ff-8de6-37993d709522"),
+	}
+	tests := []struct {
+		name     string
+		password string
+		setup    func(f fields)
+		wantErr  error
+	}{
+		{
+			name:     "success ResetPassword usecase",
+			password: "qwerty1234",
+			setup: func(f fields) {
+				f.repo.EXPECT().SetPassword(gomock.Any(), id, "qwerty1234").Return(&entity.User{ID: id}, nil)
+			},
+		},
+		{
+			name:     "empty password",
+			password: "",
+			setup:    func(f fields) {},
+			wantErr:  domain.ErrInvalid,
+		},
+		{
+			name:     "too long password",
+			password: strings.Repeat("a", MaxPasswordLength+1),
+			setup:    func(f fields) {},
+			wantErr:  domain.ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fields{
+				repo: repository.NewMockUserRepository(ctrl),
+			}
+			u := &userInteractor{
+				repo: f.repo,
+			}
+
+			tt.setup(f)
+
+			_, err := u.ResetPassword(context.Background(), id, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userInteractor.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
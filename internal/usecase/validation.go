@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения данных пользователя, общие для HTTP, gRPC и импорта.
+// Правила в proto и в view.UserCreateRequest должны им соответствовать.
+const (
+	MaxNameLength     = 50
+	MaxEmailLength    = 255
+	MaxPasswordLength = 255
+	MinAge            = 0
+	MaxAge            = 150
+)
+
+// phonePattern номер в формате E.164, не длиннее 15 символов вместе с "+" (ограничение колонки в бд)
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{1,13}$`)
+
+// IsValidPhone проверяет, что номер телефона записан в формате E.164
+func IsValidPhone(phone string) bool {
+	return phonePattern.MatchString(phone)
+}
+
+// IsValidEmail проверяет, что строка является одним адресом электронной почты без имени
+func IsValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email && len(email) <= MaxEmailLength
+}
+
+// ValidateUserCreate проверяет данные пользователя перед сохранением.
+// Возвращает ошибку вида domain.ErrInvalid со всеми нарушениями по полям.
+func ValidateUserCreate(user *entity.UserCreate) error {
+	var violations []domain.Violation
+	add := func(field, description string) {
+		violations = append(violations, domain.Violation{
+			Field:       field,
+			Description: description,
+		})
+	}
+
+	if n := utf8.RuneCountInString(user.FirstName); n == 0 || n > MaxNameLength {
+		add("first_name", "must be 1-50 characters")
+	}
+	if n := utf8.RuneCountInString(user.LastName); n == 0 || n > MaxNameLength {
+		add("last_name", "must be 1-50 characters")
+	}
+	if utf8.RuneCountInString(user.SecondName) > MaxNameLength {
+		add("second_name", "must be at most 50 characters")
+	}
+	if user.Age < MinAge || user.Age > MaxAge {
+		add("age", "must be between 0 and 150")
+	}
+	if !IsValidEmail(user.Email) {
+		add("email", "is invalid")
+	}
+	if user.Phone != "" && !IsValidPhone(user.Phone) {
+		add("phone", "must be in E.164 format")
+	}
+	if user.Role != "" && ValidateRole(user.Role) != nil {
+		add("role", "must be user or admin")
+	}
+	if user.Password == "" {
+		add("password", "is required")
+	} else if utf8.RuneCountInString(user.Password) > MaxPasswordLength {
+		add("password", "must be at most 255 characters")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.String())
+	}
+
+	return domain.InvalidFields(strings.Join(descriptions, "; "), violations...)
+}
+
+// ValidatePassword проверяет новый пароль пользователя
+func ValidatePassword(password string) error {
+	switch {
+	case password == "":
+		return invalidField("password", "is required")
+	case utf8.RuneCountInString(password) > MaxPasswordLength:
+		return invalidField("password", "must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// ValidateRole проверяет, что роль известна
+func ValidateRole(role entity.Role) error {
+	switch role {
+	case entity.RoleUser, entity.RoleAdmin:
+		return nil
+	}
+
+	return invalidField("role", "must be user or admin")
+}
+
+func invalidField(field, description string) error {
+	violation := domain.Violation{
+		Field:       field,
+		Description: description,
+	}
+
+	return domain.InvalidFields(violation.String(), violation)
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"errors"
+	"go-test-grpc-http/internal/domain"
+	"go-test-grpc-http/internal/entity"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateUserCreate(t *testing.T) {
+	valid := func() *entity.UserCreate {
+		return &entity.UserCreate{
+			FirstName: "Иван",
+			LastName:  "Иванов",
+			Age:       30,
+			Email:     "ivan@example.com",
+			Phone:     "+79991234567",
+			Password:  "qwerty1234",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(user *entity.UserCreate)
+		wantFields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(user *entity.UserCreate) {},
+		},
+		{
+			name: "valid: empty phone, cyrillic name of 50 characters",
+			modify: func(user *entity.UserCreate) {
+				user.Phone = ""
+				user.FirstName = strings.Repeat("я", 50)
+			},
+		},
+		{
+			name: "every violation is reported",
+			modify: func(user *entity.UserCreate) {
+				user.FirstName = ""
+				user.SecondName = strings.Repeat("a", 51)
+				user.Age = -1
+				user.Email = "Ivan <ivan@example.com>"
+				user.Phone = "89991234567"
+				user.Password = ""
+			},
+			wantFields: []string{"first_name", "second_name", "age", "email", "phone", "password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := valid()
+			tt.modify(user)
+
+			err := ValidateUserCreate(user)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Errorf("ValidateUserCreate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrInvalid) {
+				t.Fatalf("ValidateUserCreate() error = %v, want %v", err, domain.ErrInvalid)
+			}
+			var fields []string
+			for _, violation := range domain.ViolationsOf(err) {
+				fields = append(fields, violation.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("ValidateUserCreate() fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -173,9 +173,14 @@ deps() {
   go get ./...
 }
 
+# Создание пары файлов миграции: ./run.sh newmigrate <имя>
 newmigrate() {
-    local MIGRATENAME
-    migrate create -ext sql -dir ./cmd/go-test-grpc-http/migrations -seq $MIGRATENAME
+    local MIGRATENAME="$2"
+    if [ -z "$MIGRATENAME" ]; then
+      echo "Не указано имя миграции"
+      return 1
+    fi
+    migrate create -ext sql -dir ./internal/app/migrations -seq "$MIGRATENAME"
 }
 
 # Добавьте сюда список командx 
@@ -189,7 +194,7 @@ using() {
   echo "-unit_coverage - запуск тестов покрытия"
   echo "-html_unit_coverage - запуск тестов покрытия с генерацией html файла"
   echo "-deps - загрузка зависимостей"
-  echo "-newmigrate - создание миграции"
+  echo "-newmigrate <ИМЯ> - создание миграции"
 }
 
 ############### НЕ МЕНЯЙТЕ КОД НИЖЕ ЭТОЙ СТРОКИ #################